@@ -0,0 +1,128 @@
+package warning
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Attr is a key-value pair attached to a warning, such as a request or user ID.
+type Attr struct {
+	Key   string
+	Value any
+}
+
+type attrWarning struct {
+	wrr   Warning
+	attrs []Attr
+}
+
+// WithAttrs returns a warning with the given attributes added to the attributes it already has.
+func WithAttrs(wrr Warning, attrs ...Attr) Warning {
+	return &attrWarning{wrr, attrs}
+}
+
+func (wrr *attrWarning) Warn() string {
+	return wrr.wrr.Warn()
+}
+
+func (wrr *attrWarning) Format(state fmt.State, verb rune) {
+	formatState(state, verb, wrr)
+}
+
+func (wrr *attrWarning) String() string {
+	return stringOf(wrr.wrr)
+}
+
+func (wrr *attrWarning) MarshalJSON() ([]byte, error) {
+	return json.Marshal(wrr.wrr)
+}
+
+func (wrr *attrWarning) Attrs() []Attr {
+	return wrr.attrs
+}
+
+func (wrr *attrWarning) Unwrap() Warning {
+	return wrr.wrr
+}
+
+// AttrsOf returns the attributes of the warning and all the warnings it wraps.
+// When several attributes share a key, the one added last wins.
+func AttrsOf(wrr Warning) []Attr {
+	var attrs []Attr
+
+	seen := make(map[string]bool)
+
+	for ; wrr != nil; wrr = Unwrap(wrr) {
+		found, ok := wrr.(interface{ Attrs() []Attr })
+		if !ok {
+			continue
+		}
+
+		list := found.Attrs()
+		for i := len(list) - 1; i >= 0; i-- {
+			if seen[list[i].Key] {
+				continue
+			}
+
+			seen[list[i].Key] = true
+			attrs = append(attrs, list[i])
+		}
+	}
+
+	// attributes were collected from the outermost to the innermost, restore the order they were added in
+	for i, j := 0, len(attrs)-1; i < j; i, j = i+1, j-1 {
+		attrs[i], attrs[j] = attrs[j], attrs[i]
+	}
+
+	return attrs
+}
+
+// AttrOf returns the value of the attribute with the given key.
+func AttrOf(wrr Warning, key string) (any, bool) {
+	for _, attr := range AttrsOf(wrr) {
+		if attr.Key == key {
+			return attr.Value, true
+		}
+	}
+
+	return nil, false
+}
+
+// Extractor returns attributes read from the context a warning was written to.
+type Extractor func(ctx context.Context) []Attr
+
+// Enrich returns a new context that attaches the attributes returned by the extractors to each written warning.
+// The extractors are called at the time of [Warn] with the context passed to it, and the attributes are stored
+// on the warning itself, so they remain available after the context is gone.
+func Enrich(ctx context.Context, extractors ...Extractor) context.Context {
+	writer := getWriter(ctx)
+	if writer == nil || len(extractors) == 0 {
+		return ctx
+	}
+
+	return setWriter(ctx, &enrichWriter{writer, extractors})
+}
+
+type enrichWriter struct {
+	next       Writer
+	extractors []Extractor
+}
+
+func (writer *enrichWriter) WriteWarning(wrr Warning) error {
+	return writer.WriteWarningContext(context.Background(), wrr)
+}
+
+func (writer *enrichWriter) WriteWarningContext(ctx context.Context, wrr Warning) error {
+	var attrs []Attr
+
+	for _, extract := range writer.extractors {
+		attrs = append(attrs, extract(ctx)...)
+	}
+
+	if len(attrs) > 0 {
+		wrr = WithAttrs(wrr, attrs...)
+	}
+
+	return writeWarning(ctx, writer.next, wrr)
+}
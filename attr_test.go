@@ -0,0 +1,104 @@
+package warning_test
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+
+	"go.wamod.dev/warning"
+)
+
+type requestIDKey struct{}
+
+// ExampleEnrich demonstrates how to attach request information from the context to warnings.
+func ExampleEnrich() {
+	collector := warning.NewCollector()
+	defer collector.Close()
+
+	ctx := warning.Attach(context.Background(), collector)
+	ctx = warning.Enrich(ctx, func(ctx context.Context) []warning.Attr {
+		return []warning.Attr{{Key: "request_id", Value: ctx.Value(requestIDKey{})}}
+	})
+
+	// the request ID is read when the warning is written
+	reqCtx, cancel := context.WithCancel(context.WithValue(ctx, requestIDKey{}, "req-42"))
+	warning.Warn(reqCtx, warning.New("slow query"))
+	cancel()
+
+	wrrs, err := warning.ReadAll(collector)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, wrr := range wrrs {
+		id, _ := warning.AttrOf(wrr, "request_id")
+		fmt.Printf("%s: %s\n", id, wrr.Warn())
+	}
+
+	// Output:
+	// req-42: slow query
+}
+
+func TestWithAttrs(t *testing.T) {
+	inner := warning.New("test")
+	wrr := warning.WithAttrs(inner, warning.Attr{Key: "a", Value: 1}, warning.Attr{Key: "b", Value: 2})
+	wrr = warning.WithAttrs(wrr, warning.Attr{Key: "c", Value: 3}, warning.Attr{Key: "a", Value: 4})
+
+	if wrr.Warn() != "test" {
+		t.Errorf("expected test, got %v", wrr.Warn())
+	}
+
+	want := []warning.Attr{{Key: "b", Value: 2}, {Key: "c", Value: 3}, {Key: "a", Value: 4}}
+	if got := warning.AttrsOf(wrr); !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	if got, ok := warning.AttrOf(wrr, "a"); !ok || got != 4 {
+		t.Errorf("expected 4, got %v", got)
+	}
+
+	if _, ok := warning.AttrOf(inner, "a"); ok {
+		t.Errorf("expected no attribute")
+	}
+
+	requireForwarded(t, wrr, "test")
+}
+
+func TestEnrich(t *testing.T) {
+	writer := &mockWriter{}
+
+	ctx := warning.Attach(context.Background(), writer)
+	ctx = warning.Enrich(ctx,
+		func(ctx context.Context) []warning.Attr {
+			return []warning.Attr{{Key: "request_id", Value: ctx.Value(requestIDKey{})}}
+		},
+		func(context.Context) []warning.Attr { return nil },
+	)
+
+	warning.Warn(context.WithValue(ctx, requestIDKey{}, "req-1"), warning.New("first"))
+	warning.Warn(context.WithValue(ctx, requestIDKey{}, "req-2"), warning.New("second"))
+
+	if len(writer.buf) != 2 {
+		t.Fatalf("expected 2 warnings, got %v", writer.buf)
+	}
+
+	for i, want := range []string{"req-1", "req-2"} {
+		if got, _ := warning.AttrOf(writer.buf[i], "request_id"); got != want {
+			t.Errorf("expected %v, got %v", want, got)
+		}
+	}
+}
+
+func TestEnrichNoWriter(t *testing.T) {
+	extract := func(context.Context) []warning.Attr { return nil }
+
+	if ctx := warning.Enrich(context.Background(), extract); ctx != context.Background() {
+		t.Errorf("expected same context, got %v", ctx)
+	}
+
+	ctx := warning.Attach(context.Background(), &mockWriter{})
+	if got := warning.Enrich(ctx); got != ctx {
+		t.Errorf("expected same context, got %v", got)
+	}
+}
@@ -0,0 +1,85 @@
+package warning
+
+import (
+	"encoding/xml"
+	"io"
+	"path/filepath"
+)
+
+type checkstyleReport struct {
+	XMLName xml.Name         `xml:"checkstyle"`
+	Version string           `xml:"version,attr"`
+	Files   []checkstyleFile `xml:"file"`
+}
+
+type checkstyleFile struct {
+	Name   string            `xml:"name,attr"`
+	Errors []checkstyleError `xml:"error"`
+}
+
+type checkstyleError struct {
+	Line     int    `xml:"line,attr,omitempty"`
+	Column   int    `xml:"column,attr,omitempty"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr,omitempty"`
+}
+
+// EncodeCheckstyle writes the warnings read from r to w as a Checkstyle XML report, as read by Jenkins
+// and other CI tools. Warnings are grouped by file, warnings without a file are listed under a file with
+// an empty name. The code of a warning is written as its source, and severities map to error, warning and info.
+// Notes and the hint are written on separate lines of the message.
+func EncodeCheckstyle(w io.Writer, r Reader) error {
+	wrrs, err := ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	report := checkstyleReport{Version: "8.0"}
+
+	for _, group := range groupWarnings(wrrs, GroupByFile) {
+		file := checkstyleFile{Name: filepath.ToSlash(group.key)}
+
+		for _, wrr := range group.wrrs {
+			entry := checkstyleError{
+				Severity: checkstyleSeverity(SeverityOf(wrr)),
+				Message:  reportMessage(wrr),
+				Source:   CodeOf(wrr),
+			}
+
+			if pos, ok := PositionOf(wrr); ok {
+				entry.Line, entry.Column = pos.Line, pos.Column
+			}
+
+			file.Errors = append(file.Errors, entry)
+		}
+
+		report.Files = append(report.Files, file)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+
+	if err := enc.Encode(report); err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, "\n")
+
+	return err
+}
+
+func checkstyleSeverity(severity Severity) string {
+	switch {
+	case severity >= SeverityError:
+		return "error"
+	case severity == SeverityWarning:
+		return "warning"
+	default:
+		return "info"
+	}
+}
@@ -0,0 +1,55 @@
+package warning_test
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+
+	"go.wamod.dev/warning"
+)
+
+func TestEncodeCheckstyle(t *testing.T) {
+	var buf bytes.Buffer
+
+	if err := warning.EncodeCheckstyle(&buf, warning.NewSliceReader(reportWarnings())); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	var report struct {
+		Version string `xml:"version,attr"`
+		Files   []struct {
+			Name   string `xml:"name,attr"`
+			Errors []struct {
+				Line     int    `xml:"line,attr"`
+				Column   int    `xml:"column,attr"`
+				Severity string `xml:"severity,attr"`
+				Message  string `xml:"message,attr"`
+				Source   string `xml:"source,attr"`
+			} `xml:"error"`
+		} `xml:"file"`
+	}
+
+	if err := xml.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("expected valid XML, got %v", err)
+	}
+
+	if len(report.Files) != 3 {
+		t.Fatalf("expected 3 files, got %+v", report.Files)
+	}
+
+	for i, name := range []string{"config/app.toml", "/src/main.go", ""} {
+		if report.Files[i].Name != name {
+			t.Errorf("expected file %q, got %q", name, report.Files[i].Name)
+		}
+	}
+
+	first := report.Files[0].Errors[0]
+	if first.Line != 3 || first.Column != 1 || first.Severity != "error" || first.Source != "SARIF1" ||
+		first.Message != "unknown key \"tiemout\"\nnote: config/app.toml:3:1: defined here" {
+		t.Errorf("unexpected error %+v", first)
+	}
+
+	if got := report.Files[2].Errors; len(got) != 3 || got[0].Severity != "info" || got[0].Line != 0 {
+		t.Errorf("unexpected errors without a file %+v", got)
+	}
+}
@@ -1,6 +1,7 @@
 package warning
 
 import (
+	"context"
 	"io"
 	"sync"
 )
@@ -9,6 +10,10 @@ import (
 // It implements the [Reader], [Writer] and [io.Closer] interfaces.
 // Read operation are non-blocking and returns [io.EOF] when there are no more warnings in the buffer.
 // The collector is thread-safe. It is safe to read and write warnings concurrently.
+//
+// Warnings are stamped with a sequence number, increasing across the whole process, and the time they
+// were collected, so that warnings collected separately can be merged back in order with [MergeOrdered]
+// or [Sort]. Warnings that are already stamped keep their original stamp.
 type Collector struct {
 	buf    []Warning
 	mtx    sync.Mutex
@@ -37,6 +42,11 @@ func (c *Collector) Close() error {
 
 // WriteWarning writes a warning to the collector.
 func (c *Collector) WriteWarning(wrr Warning) error {
+	return c.WriteWarningContext(context.Background(), wrr)
+}
+
+// WriteWarningContext writes a warning to the collector, stamped with the time of the clock set by [WithClock].
+func (c *Collector) WriteWarningContext(ctx context.Context, wrr Warning) error {
 	c.mtx.Lock()
 	defer c.mtx.Unlock()
 
@@ -44,7 +54,8 @@ func (c *Collector) WriteWarning(wrr Warning) error {
 		return ErrClosed
 	}
 
-	c.buf = append(c.buf, wrr)
+	// stamp while holding the lock, so that the buffer stays sorted
+	c.buf = append(c.buf, stamp(wrr, clockFrom(ctx)))
 
 	return nil
 }
@@ -0,0 +1,57 @@
+package warning
+
+import (
+	"context"
+	"fmt"
+)
+
+// DoneMode controls how a writer handles warnings written to a context that is done,
+// for example because the request that emitted them was cancelled.
+type DoneMode int
+
+const (
+	// DoneKeep writes warnings regardless of the context state. It is the default behavior.
+	DoneKeep DoneMode = iota
+	// DoneDrop drops warnings written to a done context and reports it with [ErrDropped].
+	DoneDrop
+	// DoneTag writes warnings written to a done context with the [TagLate] tag.
+	DoneTag
+)
+
+// OnDone returns a Writer that handles warnings written to a done context according to mode.
+// Attach the returned writer to configure the behavior per writer:
+//
+//	ctx = warning.Attach(ctx, warning.OnDone(collector, warning.DoneDrop))
+//
+// When a warning is dropped, [Warn] returns an error wrapping both [ErrDropped] and the context error.
+// Warnings written without a context, by calling WriteWarning directly, are always written.
+func OnDone(w Writer, mode DoneMode) Writer {
+	if mode == DoneKeep {
+		return w
+	}
+
+	return &doneWriter{w, mode}
+}
+
+type doneWriter struct {
+	next Writer
+	mode DoneMode
+}
+
+func (writer *doneWriter) WriteWarning(wrr Warning) error {
+	return writer.next.WriteWarning(wrr)
+}
+
+func (writer *doneWriter) WriteWarningContext(ctx context.Context, wrr Warning) error {
+	err := ctx.Err()
+
+	switch {
+	case err == nil:
+	case writer.mode == DoneDrop:
+		return fmt.Errorf("%w: %w", ErrDropped, err)
+	case writer.mode == DoneTag:
+		wrr = WithTags(wrr, TagLate)
+	}
+
+	return writeWarning(ctx, writer.next, wrr)
+}
@@ -0,0 +1,74 @@
+package warning_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.wamod.dev/warning"
+)
+
+func TestOnDone(t *testing.T) {
+	kept := &mockWriter{}
+	dropped := &mockWriter{}
+	tagged := &mockWriter{}
+
+	ctx := warning.Attach(context.Background(), warning.OnDone(kept, warning.DoneKeep))
+	ctx = warning.Attach(ctx, warning.OnDone(dropped, warning.DoneDrop))
+	ctx = warning.Attach(ctx, warning.OnDone(tagged, warning.DoneTag))
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	if err := warning.Warn(ctx, warning.New("early")); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	cancel()
+
+	err := warning.Warn(ctx, warning.New("late"))
+	if !errors.Is(err, warning.ErrDropped) || !errors.Is(err, context.Canceled) {
+		t.Errorf("expected %v and %v, got %v", warning.ErrDropped, context.Canceled, err)
+	}
+
+	if len(kept.buf) != 2 {
+		t.Errorf("expected 2 kept warnings, got %v", kept.buf)
+	}
+
+	if len(dropped.buf) != 1 || dropped.buf[0].Warn() != "early" {
+		t.Errorf("expected only the early warning, got %v", dropped.buf)
+	}
+
+	if len(tagged.buf) != 2 {
+		t.Fatalf("expected 2 tagged warnings, got %v", tagged.buf)
+	}
+
+	if warning.HasTag(tagged.buf[0], warning.TagLate) {
+		t.Errorf("expected early warning without %v tag", warning.TagLate)
+	}
+
+	if !warning.HasTag(tagged.buf[1], warning.TagLate) {
+		t.Errorf("expected late warning with %v tag", warning.TagLate)
+	}
+}
+
+func TestOnDoneDeadline(t *testing.T) {
+	writer := &mockWriter{}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 0)
+	defer cancel()
+
+	ctx = warning.Attach(ctx, warning.OnDone(writer, warning.DoneDrop))
+
+	if err := warning.Warn(ctx, warning.New("test")); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected %v, got %v", context.DeadlineExceeded, err)
+	}
+
+	// writing without a context bypasses the check
+	if err := warning.OnDone(writer, warning.DoneDrop).WriteWarning(warning.New("test")); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	if len(writer.buf) != 1 {
+		t.Errorf("expected 1 warning, got %v", writer.buf)
+	}
+}
@@ -0,0 +1,178 @@
+package warning
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path"
+	"strings"
+)
+
+// EnvVar is the name of the environment variable read by [ApplyEnv].
+const EnvVar = "GOWARNINGS"
+
+// ParseEnv parses warning controls in the GOWARNINGS format and returns the equivalent [Policy].
+//
+// The value is a comma-separated list of selector=action entries:
+//
+//	GOWARNINGS = entry { "," entry }
+//	entry      = selector "=" action
+//	selector   = "*" | severity | tag | code
+//	action     = "on" | "off" | "log" | severity
+//	severity   = "hint" | "info" | "warning" | "error"
+//
+// A code selector may contain [path.Match] wildcards, e.g. W10*. A selector that is not a severity
+// matches warnings having it either as their code or as one of their tags, e.g. deprecation.
+// The "on" action keeps warnings, "off" drops them, "log" routes them to the standard logger
+// instead of the attached writer, and a severity name rewrites the severity of the warnings.
+//
+// The most specific entry wins: code selectors go first, then tags, then severities and "*" last.
+// If the same selector is given more than once, the last entry is used. For example:
+//
+//	GOWARNINGS=deprecation=error,W1042=off,*=log
+//
+// escalates deprecations to errors, silences W1042 even if it is a deprecation
+// and sends all the other warnings to the standard logger.
+func ParseEnv(value string) (*Policy, error) {
+	actions := make(map[string]PolicyRule)
+
+	var selectors []string
+
+	for i, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		selector, action, ok := strings.Cut(entry, "=")
+		selector, action = strings.TrimSpace(selector), strings.TrimSpace(action)
+
+		if !ok || selector == "" || action == "" {
+			return nil, fmt.Errorf("%w: entry %d %q: expected selector=action", ErrInvalidEnv, i+1, entry)
+		}
+
+		if _, err := path.Match(selector, ""); err != nil {
+			return nil, fmt.Errorf("%w: entry %d %q: selector: %w", ErrInvalidEnv, i+1, entry, err)
+		}
+
+		rule, err := parseEnvAction(action)
+		if err != nil {
+			return nil, fmt.Errorf("%w: entry %d %q: %w", ErrInvalidEnv, i+1, entry, err)
+		}
+
+		if _, ok := actions[selector]; !ok {
+			selectors = append(selectors, selector)
+		}
+
+		actions[selector] = rule
+	}
+
+	var codes, tags, severities, all []PolicyRule
+
+	for _, selector := range selectors {
+		rule := actions[selector]
+
+		switch severity, err := ParseSeverity(selector); {
+		case selector == "*":
+			all = append(all, rule)
+		case err == nil:
+			rule.Match.Severity = severity.String()
+			severities = append(severities, rule)
+		default:
+			rule.Match.Code = selector
+			codes = append(codes, rule)
+
+			rule.Match = PolicyMatch{Tag: Tag(selector)}
+			tags = append(tags, rule)
+		}
+	}
+
+	rules := make([]PolicyRule, 0, len(codes)+len(tags)+len(severities)+len(all))
+	rules = append(rules, codes...)
+	rules = append(rules, tags...)
+	rules = append(rules, severities...)
+	rules = append(rules, all...)
+
+	return NewPolicy(rules...)
+}
+
+func parseEnvAction(action string) (PolicyRule, error) {
+	switch strings.ToLower(action) {
+	case "on":
+		return PolicyRule{Action: PolicyKeep}, nil
+	case "off":
+		return PolicyRule{Action: PolicyDrop}, nil
+	case "log":
+		return PolicyRule{Sink: envLogSink}, nil
+	}
+
+	severity, err := ParseSeverity(action)
+	if err != nil {
+		return PolicyRule{}, fmt.Errorf("unknown action %q, expected on, off, log or a severity", action) //nolint:err113
+	}
+
+	return PolicyRule{Severity: severity.String()}, nil
+}
+
+const envLogSink = "log"
+
+// ApplyEnv parses the GOWARNINGS environment variable using [ParseEnv] and returns a new context
+// that applies the resulting policy in front of the attached writer. It is meant to be called once
+// at startup on the base context of the program. If the variable is not set, ctx is returned unchanged.
+//
+// The policy only applies to warnings written to the returned context and the contexts derived from it,
+// use [ApplyEnvDefault] to also apply it to warnings going to the [Default] writer.
+func ApplyEnv(ctx context.Context) (context.Context, error) {
+	policy, err := envPolicy()
+	if err != nil || policy == nil {
+		return ctx, err
+	}
+
+	return ApplyPolicy(ctx, policy, envSinks())
+}
+
+// ApplyEnvDefault parses the GOWARNINGS environment variable using [ParseEnv] and makes the default
+// writer apply the resulting policy in front of the current [Default] writer, so that it also applies
+// to warnings written to contexts without an attached writer, such as those of libraries using
+// [context.Background]. It is meant to be called once at startup, after [SetDefault]: a later call
+// to SetDefault replaces the policy along with the default writer. If the variable is not set,
+// the default writer is left unchanged.
+func ApplyEnvDefault() error {
+	policy, err := envPolicy()
+	if err != nil || policy == nil {
+		return err
+	}
+
+	SetDefault(&policyWriter{Default(), policy, envSinks()})
+
+	return nil
+}
+
+// envPolicy parses the GOWARNINGS environment variable. It returns nil if the variable is not set.
+func envPolicy() (*Policy, error) {
+	value := os.Getenv(EnvVar)
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+
+	return ParseEnv(value)
+}
+
+func envSinks() map[string]Writer {
+	return map[string]Writer{envLogSink: logWriter{}}
+}
+
+// logWriter writes warnings to the standard logger.
+type logWriter struct{}
+
+func (logWriter) WriteWarning(wrr Warning) error {
+	prefix := SeverityOf(wrr).String()
+	if code := CodeOf(wrr); code != "" {
+		prefix += "[" + code + "]"
+	}
+
+	log.Print(prefix + ": " + wrr.Warn())
+
+	return nil
+}
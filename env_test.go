@@ -0,0 +1,195 @@
+package warning_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"os"
+	"strings"
+	"testing"
+
+	"go.wamod.dev/warning"
+)
+
+func TestParseEnv(t *testing.T) {
+	policy, err := warning.ParseEnv("deprecation=error, W1042=off, info=off, W2*=on, *=log")
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	deprecated := warning.WithTags(warning.New("test"), warning.TagDeprecation)
+
+	tests := []struct {
+		name     string
+		wrr      warning.Warning
+		keep     bool
+		sink     string
+		severity warning.Severity
+	}{
+		{"tag", deprecated, true, "", warning.SeverityError},
+		{"code before tag", warning.WithCode(deprecated, "W1042"), false, "", 0},
+		{"code as tag", warning.WithTags(warning.New("test"), "W1042"), false, "", 0},
+		{"code pattern", warning.WithCode(warning.WithSeverity(warning.New("test"), warning.SeverityInfo), "W21"), true, "", warning.SeverityInfo},
+		{"severity", warning.WithSeverity(warning.New("test"), warning.SeverityInfo), false, "", 0},
+		{"default", warning.New("test"), true, "log", warning.SeverityWarning},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wrr, sink, keep := policy.Apply(tt.wrr)
+
+			if keep != tt.keep {
+				t.Fatalf("expected keep %v, got %v", tt.keep, keep)
+			}
+
+			if !keep {
+				return
+			}
+
+			if sink != tt.sink {
+				t.Errorf("expected sink %q, got %q", tt.sink, sink)
+			}
+
+			if got := warning.SeverityOf(wrr); got != tt.severity {
+				t.Errorf("expected severity %v, got %v", tt.severity, got)
+			}
+		})
+	}
+}
+
+func TestParseEnv_LastWins(t *testing.T) {
+	policy, err := warning.ParseEnv("W1=off,W1=error")
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	wrr, _, keep := policy.Apply(warning.WithCode(warning.New("test"), "W1"))
+	if !keep || warning.SeverityOf(wrr) != warning.SeverityError {
+		t.Errorf("expected W1 to be escalated, got keep=%v severity=%v", keep, warning.SeverityOf(wrr))
+	}
+}
+
+func TestParseEnv_Errors(t *testing.T) {
+	tests := map[string]string{
+		"W1":          `entry 1 "W1": expected selector=action`,
+		"W1=on,=off":  `entry 2 "=off": expected selector=action`,
+		"W1=":         `entry 1 "W1=": expected selector=action`,
+		"W1=loud":     `entry 1 "W1=loud": unknown action "loud"`,
+		"[W1=off,*=x": `entry 1 "[W1=off": selector: syntax error in pattern`,
+	}
+
+	for value, want := range tests {
+		_, err := warning.ParseEnv(value)
+		if !errors.Is(err, warning.ErrInvalidEnv) {
+			t.Fatalf("expected %v, got %v", warning.ErrInvalidEnv, err)
+		}
+
+		if want = "invalid GOWARNINGS: " + want; !strings.HasPrefix(err.Error(), want) {
+			t.Errorf("expected %q, got %q", want, err)
+		}
+	}
+}
+
+func TestApplyEnv(t *testing.T) {
+	var logs bytes.Buffer
+
+	log.SetOutput(&logs)
+	log.SetFlags(0)
+
+	defer func() {
+		log.SetOutput(os.Stderr)
+		log.SetFlags(log.LstdFlags)
+	}()
+
+	t.Setenv(warning.EnvVar, "W1=off,W2=log")
+
+	writer := &mockWriter{}
+
+	ctx, err := warning.ApplyEnv(warning.Attach(context.Background(), writer))
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	warning.Warn(ctx,
+		warning.WithCode(warning.New("test-1"), "W1"),
+		warning.WithCode(warning.New("test-2"), "W2"),
+		warning.New("test-3"),
+	)
+
+	if len(writer.buf) != 1 || writer.buf[0].Warn() != "test-3" {
+		t.Errorf("expected test-3, got %v", writer.buf)
+	}
+
+	if got := logs.String(); got != "warning[W2]: test-2\n" {
+		t.Errorf("expected warning[W2]: test-2, got %q", got)
+	}
+}
+
+func TestApplyEnvDefault(t *testing.T) {
+	var logs bytes.Buffer
+
+	log.SetOutput(&logs)
+	log.SetFlags(0)
+
+	defer func() {
+		log.SetOutput(os.Stderr)
+		log.SetFlags(log.LstdFlags)
+	}()
+
+	writer := &mockWriter{}
+
+	warning.SetDefault(writer)
+	defer warning.SetDefault(nil)
+
+	t.Setenv(warning.EnvVar, "")
+
+	if err := warning.ApplyEnvDefault(); err != nil || warning.Default() != writer {
+		t.Fatalf("expected default writer to be left unchanged, got %v, %v", warning.Default(), err)
+	}
+
+	t.Setenv(warning.EnvVar, "W1=off,W2=log")
+
+	if err := warning.ApplyEnvDefault(); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	warning.Warn(context.Background(),
+		warning.WithCode(warning.New("test-1"), "W1"),
+		warning.WithCode(warning.New("test-2"), "W2"),
+		warning.New("test-3"),
+	)
+
+	if len(writer.buf) != 1 || writer.buf[0].Warn() != "test-3" {
+		t.Errorf("expected test-3, got %v", writer.buf)
+	}
+
+	if got := logs.String(); got != "warning[W2]: test-2\n" {
+		t.Errorf("expected warning[W2]: test-2, got %q", got)
+	}
+
+	t.Setenv(warning.EnvVar, "invalid")
+
+	if err := warning.ApplyEnvDefault(); !errors.Is(err, warning.ErrInvalidEnv) {
+		t.Errorf("expected %v, got %v", warning.ErrInvalidEnv, err)
+	}
+}
+
+func TestApplyEnv_Unset(t *testing.T) {
+	t.Setenv(warning.EnvVar, "")
+
+	ctx, err := warning.ApplyEnv(context.Background())
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	if ctx != context.Background() {
+		t.Errorf("expected same context, got %v", ctx)
+	}
+
+	t.Setenv(warning.EnvVar, "invalid")
+
+	if _, err := warning.ApplyEnv(context.Background()); !errors.Is(err, warning.ErrInvalidEnv) {
+		t.Errorf("expected %v, got %v", warning.ErrInvalidEnv, err)
+	}
+}
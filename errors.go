@@ -4,3 +4,86 @@ import "fmt"
 
 // ErrClosed is returned when the warning stream is closed.
 var ErrClosed = fmt.Errorf("warning stream is closed")
+
+// ErrOverlappingEdits is returned when suggested fixes contain edits that overlap each other.
+var ErrOverlappingEdits = fmt.Errorf("overlapping edits")
+
+// ErrInvalidEdit is returned when an edit refers to a range that cannot be resolved in its source file.
+var ErrInvalidEdit = fmt.Errorf("invalid edit")
+
+// ErrUnknownSeverity is returned when parsing a severity that is not known.
+var ErrUnknownSeverity = fmt.Errorf("unknown severity")
+
+// ErrInvalidDefinition is returned when registering a warning definition without a code.
+var ErrInvalidDefinition = fmt.Errorf("invalid warning definition")
+
+// ErrDuplicateCode is returned when registering a warning code that is already registered.
+var ErrDuplicateCode = fmt.Errorf("duplicate warning code")
+
+// ErrInvalidPolicy is returned when a warning policy cannot be parsed or validated.
+var ErrInvalidPolicy = fmt.Errorf("invalid warning policy")
+
+// ErrUnknownSink is returned when a policy routes a warning to a sink that was not provided.
+var ErrUnknownSink = fmt.Errorf("unknown warning sink")
+
+// ErrDropped is returned when a warning is dropped because the context it was written to is done.
+// The returned error also wraps the context error, [context.Canceled] or [context.DeadlineExceeded].
+var ErrDropped = fmt.Errorf("warning dropped")
+
+// ErrInvalidType is returned when registering a custom warning type without a name or a pointer constructor.
+var ErrInvalidType = fmt.Errorf("invalid warning type")
+
+// ErrDuplicateType is returned when registering a custom warning type name or type that is already registered.
+var ErrDuplicateType = fmt.Errorf("duplicate warning type")
+
+// ErrUnsupportedVersion is returned when decoding warnings written with a newer version of a schema.
+var ErrUnsupportedVersion = fmt.Errorf("unsupported schema version")
+
+// ErrInvalidRecord is returned when decoding a warning record with a field of the wrong shape.
+var ErrInvalidRecord = fmt.Errorf("invalid warning record")
+
+// PolicyError describes a problem with a single rule of a warning policy.
+type PolicyError struct {
+	// Rule is the index of the offending rule.
+	Rule int
+	// Field is the name of the offending field of the rule, if known.
+	Field string
+	// Err is the underlying error.
+	Err error
+}
+
+func (err *PolicyError) Error() string {
+	if err.Field == "" {
+		return fmt.Sprintf("%s: rules[%d]: %s", ErrInvalidPolicy, err.Rule, err.Err)
+	}
+
+	return fmt.Sprintf("%s: rules[%d].%s: %s", ErrInvalidPolicy, err.Rule, err.Field, err.Err)
+}
+
+// Unwrap returns the underlying error.
+func (err *PolicyError) Unwrap() []error {
+	return []error{ErrInvalidPolicy, err.Err}
+}
+
+// ErrInvalidEnv is returned when the GOWARNINGS environment variable cannot be parsed.
+var ErrInvalidEnv = fmt.Errorf("invalid " + EnvVar)
+
+// ErrInvalidExpr is returned when a filter expression cannot be compiled.
+var ErrInvalidExpr = fmt.Errorf("invalid filter expression")
+
+// ExprError describes a syntax or type error in a filter expression.
+type ExprError struct {
+	// Column is the 1-based column of the problem in the expression.
+	Column int
+	// Msg describes the problem.
+	Msg string
+}
+
+func (err *ExprError) Error() string {
+	return fmt.Sprintf("%s: column %d: %s", ErrInvalidExpr, err.Column, err.Msg)
+}
+
+// Unwrap returns [ErrInvalidExpr].
+func (err *ExprError) Unwrap() error {
+	return ErrInvalidExpr
+}
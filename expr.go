@@ -0,0 +1,564 @@
+package warning
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Expr is a compiled filter expression. Expressions compare warning fields with literals
+// and combine the comparisons with boolean operators:
+//
+//	severity >= warning && code ~ "W1*" && !message contains "legacy"
+//
+// The following fields are available:
+//
+//	message  string    the warning message
+//	code     string    the warning code
+//	scope    string    the scope the warning was written in
+//	path     string    the dotted field path, e.g. servers[2].tls
+//	file     string    the file name of the position
+//	line     int       the line of the position
+//	column   int       the column of the position
+//	hint     string    the hint
+//	severity severity  one of hint, info, warning or error
+//	tags     list      the tags of the warning
+//
+// Comparison operators are ==, !=, <, <=, > and >= for ints and severities, == and != for strings,
+// ~ matching a [path.Match] pattern, matches matching a regular expression and contains testing
+// for a substring or, for lists, an element. Patterns and regular expressions must be string literals.
+// Comparisons are combined with ! (not), && (and) and || (or), in order of decreasing precedence,
+// and can be grouped with parentheses. String literals use Go syntax. Severity names are literals,
+// hint is read as a severity when it is compared with one, e.g. severity == hint, and as the field otherwise.
+type Expr struct {
+	src   string
+	match func(Warning) bool
+}
+
+// CompileExpr parses and type checks the filter expression.
+// Errors are reported as [*ExprError] pointing at the column of the problem.
+func CompileExpr(src string) (*Expr, error) {
+	toks, err := lexExpr(src)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &exprParser{toks: toks}
+
+	match, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, tok.errorf("unexpected %s", tok)
+	}
+
+	return &Expr{src, match}, nil
+}
+
+// MustCompileExpr is like [CompileExpr] but panics if the expression cannot be compiled.
+func MustCompileExpr(src string) *Expr {
+	expr, err := CompileExpr(src)
+	if err != nil {
+		panic(err)
+	}
+
+	return expr
+}
+
+// Match reports whether the warning matches the expression.
+func (e *Expr) Match(wrr Warning) bool {
+	return e.match(wrr)
+}
+
+// String returns the source of the expression.
+func (e *Expr) String() string {
+	return e.src
+}
+
+// FilterExpr returns a new context that only writes the warnings matching the expression.
+// See [Expr] for the syntax of expressions.
+func FilterExpr(ctx context.Context, expr string) (context.Context, error) {
+	compiled, err := CompileExpr(expr)
+	if err != nil {
+		return ctx, err
+	}
+
+	return Filter(ctx, compiled.Match), nil
+}
+
+// NewExprReader returns a Reader that only reads the warnings of r matching the expression.
+// See [Expr] for the syntax of expressions.
+func NewExprReader(r Reader, expr string) (Reader, error) {
+	compiled, err := CompileExpr(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewFilterReader(r, compiled.Match), nil
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokString
+	tokInt
+	tokOp
+)
+
+type exprToken struct {
+	kind tokenKind
+	text string
+	col  int
+}
+
+func (tok exprToken) String() string {
+	switch tok.kind {
+	case tokEOF:
+		return "end of expression"
+	case tokString:
+		return "string " + tok.text
+	default:
+		return strconv.Quote(tok.text)
+	}
+}
+
+func (tok exprToken) errorf(format string, args ...any) *ExprError {
+	return &ExprError{tok.col, fmt.Sprintf(format, args...)}
+}
+
+func lexExpr(src string) ([]exprToken, error) {
+	var toks []exprToken
+
+	for i := 0; i < len(src); {
+		c := src[i]
+
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+
+			continue
+		case c == '"' || c == '`':
+			end := i + 1
+			for end < len(src) && src[end] != c {
+				if src[end] == '\\' && c == '"' {
+					end++
+				}
+
+				end++
+			}
+
+			if end >= len(src) {
+				return nil, &ExprError{i + 1, "unterminated string"}
+			}
+
+			if _, err := strconv.Unquote(src[i : end+1]); err != nil {
+				return nil, &ExprError{i + 1, "invalid string " + src[i:end+1]}
+			}
+
+			toks = append(toks, exprToken{tokString, src[i : end+1], i + 1})
+			i = end + 1
+		case isDigit(c):
+			end := i
+			for end < len(src) && isDigit(src[end]) {
+				end++
+			}
+
+			toks = append(toks, exprToken{tokInt, src[i:end], i + 1})
+			i = end
+		case isIdentByte(c) && !isDigit(c):
+			end := i
+			for end < len(src) && isIdentByte(src[end]) {
+				end++
+			}
+
+			toks = append(toks, exprToken{tokIdent, src[i:end], i + 1})
+			i = end
+		default:
+			op := lexOp(src[i:])
+			if op == "" {
+				return nil, &ExprError{i + 1, fmt.Sprintf("unexpected character %q", c)}
+			}
+
+			toks = append(toks, exprToken{tokOp, op, i + 1})
+			i += len(op)
+		}
+	}
+
+	return append(toks, exprToken{tokEOF, "", len(src) + 1}), nil
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isIdentByte(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || isDigit(c)
+}
+
+func lexOp(src string) string {
+	for _, op := range []string{"&&", "||", "==", "!=", "<=", ">=", "<", ">", "!", "~", "(", ")"} {
+		if strings.HasPrefix(src, op) {
+			return op
+		}
+	}
+
+	return ""
+}
+
+type exprParser struct {
+	toks []exprToken
+	pos  int
+}
+
+func (p *exprParser) peek() exprToken {
+	return p.toks[p.pos]
+}
+
+func (p *exprParser) next() exprToken {
+	tok := p.toks[p.pos]
+	if tok.kind != tokEOF {
+		p.pos++
+	}
+
+	return tok
+}
+
+func (p *exprParser) parseOr() (func(Warning) bool, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().text == "||" && p.peek().kind == tokOp {
+		p.next()
+
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+
+		left = orMatch(left, right)
+	}
+
+	return left, nil
+}
+
+func (p *exprParser) parseAnd() (func(Warning) bool, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().text == "&&" && p.peek().kind == tokOp {
+		p.next()
+
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+
+		left = andMatch(left, right)
+	}
+
+	return left, nil
+}
+
+func orMatch(left, right func(Warning) bool) func(Warning) bool {
+	return func(wrr Warning) bool { return left(wrr) || right(wrr) }
+}
+
+func andMatch(left, right func(Warning) bool) func(Warning) bool {
+	return func(wrr Warning) bool { return left(wrr) && right(wrr) }
+}
+
+func (p *exprParser) parseNot() (func(Warning) bool, error) {
+	tok := p.peek()
+
+	switch {
+	case tok.kind == tokOp && tok.text == "!":
+		p.next()
+
+		inner, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+
+		return func(wrr Warning) bool { return !inner(wrr) }, nil
+	case tok.kind == tokOp && tok.text == "(":
+		p.next()
+
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		if closing := p.next(); closing.kind != tokOp || closing.text != ")" {
+			return nil, closing.errorf("expected \")\", found %s", closing)
+		}
+
+		return inner, nil
+	default:
+		return p.parseComparison()
+	}
+}
+
+type exprType int
+
+const (
+	typeString exprType = iota
+	typeInt
+	typeSeverity
+	typeList
+)
+
+func (t exprType) String() string {
+	return [...]string{"string", "int", "severity", "list"}[t]
+}
+
+// operand is a typed value of an expression. Only the function matching its type is set.
+type operand struct {
+	typ     exprType
+	str     func(Warning) string
+	num     func(Warning) int
+	list    func(Warning) []string
+	literal *exprToken
+	// field is the token of a field operand
+	field *exprToken
+}
+
+func (p *exprParser) parseOperand() (operand, error) {
+	tok := p.next()
+
+	switch tok.kind {
+	case tokString:
+		s, _ := strconv.Unquote(tok.text)
+
+		return operand{typ: typeString, str: func(Warning) string { return s }, literal: &tok}, nil
+	case tokInt:
+		n, err := strconv.Atoi(tok.text)
+		if err != nil {
+			return operand{}, tok.errorf("invalid int %s", tok.text)
+		}
+
+		return operand{typ: typeInt, num: func(Warning) int { return n }, literal: &tok}, nil
+	case tokIdent:
+		if field, ok := exprField(tok.text); ok {
+			field.field = &tok
+
+			return field, nil
+		}
+
+		if literal, ok := severityLiteral(tok); ok {
+			return literal, nil
+		}
+
+		return operand{}, tok.errorf("unknown field %q", tok.text)
+	default:
+		return operand{}, tok.errorf("expected field or literal, found %s", tok)
+	}
+}
+
+func severityLiteral(tok exprToken) (operand, bool) {
+	severity, err := ParseSeverity(tok.text)
+	if err != nil {
+		return operand{}, false
+	}
+
+	return operand{typ: typeSeverity, num: func(Warning) int { return int(severity) }, literal: &tok}, true
+}
+
+// asSeverity returns the severity literal named like the field operand, such as hint,
+// which is how a field name is read when it is compared with a severity.
+func asSeverity(op operand) operand {
+	if op.field == nil {
+		return op
+	}
+
+	if literal, ok := severityLiteral(*op.field); ok {
+		return literal
+	}
+
+	return op
+}
+
+func exprField(name string) (operand, bool) {
+	str := func(f func(Warning) string) (operand, bool) { return operand{typ: typeString, str: f}, true }
+	num := func(f func(Warning) int) (operand, bool) { return operand{typ: typeInt, num: f}, true }
+
+	switch name {
+	case "message":
+		return str(func(wrr Warning) string { return wrr.Warn() })
+	case "code":
+		return str(CodeOf)
+	case "scope":
+		return str(ScopeOf)
+	case "hint":
+		return str(HintOf)
+	case "path":
+		return str(func(wrr Warning) string { p, _ := PathOf(wrr); return p.String() })
+	case "file":
+		return str(func(wrr Warning) string { pos, _ := PositionOf(wrr); return pos.Filename })
+	case "line":
+		return num(func(wrr Warning) int { pos, _ := PositionOf(wrr); return pos.Line })
+	case "column":
+		return num(func(wrr Warning) int { pos, _ := PositionOf(wrr); return pos.Column })
+	case "severity":
+		return operand{typ: typeSeverity, num: func(wrr Warning) int { return int(SeverityOf(wrr)) }}, true
+	case "tags":
+		return operand{typ: typeList, list: func(wrr Warning) []string {
+			tags := TagsOf(wrr)
+			list := make([]string, len(tags))
+
+			for i, tag := range tags {
+				list[i] = string(tag)
+			}
+
+			return list
+		}}, true
+	default:
+		return operand{}, false
+	}
+}
+
+func (p *exprParser) parseComparison() (func(Warning) bool, error) {
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	op := p.next()
+
+	isOp := op.kind == tokOp && slices.Contains([]string{"==", "!=", "<", "<=", ">", ">=", "~"}, op.text)
+	isWord := op.kind == tokIdent && (op.text == "contains" || op.text == "matches")
+
+	if !isOp && !isWord {
+		return nil, op.errorf("expected comparison operator, found %s", op)
+	}
+
+	right, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	return compileComparison(left, op, right)
+}
+
+func compileComparison(left operand, op exprToken, right operand) (func(Warning) bool, error) {
+	switch op.text {
+	case "==", "!=", "<", "<=", ">", ">=":
+		return compileOrdered(left, op, right)
+	case "~", "matches":
+		if left.typ != typeString && left.typ != typeList {
+			return nil, op.errorf("operator %s needs a string or a list, found %s", op.text, left.typ)
+		}
+
+		if right.typ != typeString || right.literal == nil {
+			return nil, op.errorf("operator %s needs a string literal pattern", op.text)
+		}
+
+		pattern := right.str(nil)
+
+		match, err := compilePattern(op.text, pattern)
+		if err != nil {
+			return nil, right.literal.errorf("%s", err)
+		}
+
+		return anyMatch(left, match), nil
+	default: // contains
+		if right.typ != typeString {
+			return nil, op.errorf("operator contains needs a string, found %s", right.typ)
+		}
+
+		switch left.typ {
+		case typeString:
+			return func(wrr Warning) bool { return strings.Contains(left.str(wrr), right.str(wrr)) }, nil
+		case typeList:
+			return func(wrr Warning) bool { return slices.Contains(left.list(wrr), right.str(wrr)) }, nil
+		default:
+			return nil, op.errorf("operator contains needs a string or a list, found %s", left.typ)
+		}
+	}
+}
+
+func compilePattern(op, pattern string) (func(string) bool, error) {
+	if op == "matches" {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, err
+		}
+
+		return re.MatchString, nil
+	}
+
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, err
+	}
+
+	return func(s string) bool {
+		ok, _ := path.Match(pattern, s)
+
+		return ok
+	}, nil
+}
+
+func anyMatch(left operand, match func(string) bool) func(Warning) bool {
+	if left.typ == typeList {
+		return func(wrr Warning) bool { return slices.ContainsFunc(left.list(wrr), match) }
+	}
+
+	return func(wrr Warning) bool { return match(left.str(wrr)) }
+}
+
+func compileOrdered(left operand, op exprToken, right operand) (func(Warning) bool, error) {
+	if left.typ == typeSeverity {
+		right = asSeverity(right)
+	}
+
+	if right.typ == typeSeverity {
+		left = asSeverity(left)
+	}
+
+	if left.typ != right.typ {
+		return nil, op.errorf("mismatched types %s and %s", left.typ, right.typ)
+	}
+
+	switch left.typ {
+	case typeString:
+		switch op.text {
+		case "==":
+			return func(wrr Warning) bool { return left.str(wrr) == right.str(wrr) }, nil
+		case "!=":
+			return func(wrr Warning) bool { return left.str(wrr) != right.str(wrr) }, nil
+		}
+	case typeInt, typeSeverity:
+		return compareInts(op.text, left.num, right.num), nil
+	case typeList:
+	}
+
+	return nil, op.errorf("operator %s is not defined for %s", op.text, left.typ)
+}
+
+func compareInts(op string, left, right func(Warning) int) func(Warning) bool {
+	switch op {
+	case "==":
+		return func(wrr Warning) bool { return left(wrr) == right(wrr) }
+	case "!=":
+		return func(wrr Warning) bool { return left(wrr) != right(wrr) }
+	case "<":
+		return func(wrr Warning) bool { return left(wrr) < right(wrr) }
+	case "<=":
+		return func(wrr Warning) bool { return left(wrr) <= right(wrr) }
+	case ">":
+		return func(wrr Warning) bool { return left(wrr) > right(wrr) }
+	default:
+		return func(wrr Warning) bool { return left(wrr) >= right(wrr) }
+	}
+}
@@ -0,0 +1,201 @@
+package warning_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"go.wamod.dev/warning"
+)
+
+// ExampleFilterExpr demonstrates how to filter warnings with an expression, e.g. from a CLI flag.
+func ExampleFilterExpr() {
+	// create a new collector
+	collector := warning.NewCollector()
+	defer collector.Close() // make sure to close the collector when done
+
+	// attach the collector to a context
+	ctx := warning.Attach(context.Background(), collector)
+
+	// use FilterExpr to filter warnings
+	ctx, err := warning.FilterExpr(ctx, `severity >= warning && code ~ "W1*" && !message contains "legacy"`)
+	if err != nil {
+		panic(err)
+	}
+
+	warning.Warn(ctx,
+		warning.WithCode(warning.New("this is a warning"), "W12"),
+		warning.WithCode(warning.New("legacy option is set"), "W13"),
+		warning.WithCode(warning.New("this is another warning"), "W2"),
+		warning.WithCode(warning.WithSeverity(warning.New("this is information"), warning.SeverityInfo), "W14"),
+	)
+
+	// read all warning from the collector
+	wrrs, err := warning.ReadAll(collector)
+	if err != nil {
+		panic(err)
+	}
+
+	for i, wrr := range wrrs {
+		fmt.Printf("[%d]: %s\n", i, wrr.Warn())
+	}
+
+	// Output:
+	// [0]: this is a warning
+}
+
+func TestCompileExpr(t *testing.T) {
+	wrr := warning.WithCode(warning.WithPosition(warning.WithTags(warning.WithHint(
+		warning.WithPath(warning.New(`unknown key "tiemout"`), warning.Path{}.Field("server").Field("tiemout")),
+		"did you mean timeout?"), warning.TagDeprecation),
+		warning.Position{Filename: "config.toml", Line: 12, Column: 5}), "W1042")
+
+	tests := map[string]bool{
+		`message == "unknown key \"tiemout\""`:     true,
+		"message == `unknown key \"tiemout\"`":     true,
+		`message != "other"`:                       true,
+		`message contains "tiemout"`:               true,
+		`message matches "^unknown key"`:           true,
+		`message matches "^key"`:                   false,
+		`code ~ "W10*"`:                            true,
+		`code ~ "W2*"`:                             false,
+		`path == "server.tiemout"`:                 true,
+		`file ~ "*.toml" && line == 12`:            true,
+		`column >= 5 && column < 6`:                true,
+		`line > 12 || column <= 4`:                 false,
+		`severity == warning`:                      true,
+		`severity > info && severity < error`:      true,
+		`warning <= severity`:                      true,
+		`severity == hint`:                         false,
+		`severity > hint`:                          true,
+		`hint != severity`:                         true,
+		`tags contains "deprecation"`:              true,
+		`tags ~ "dep*"`:                            true,
+		`tags matches "^unn"`:                      false,
+		`hint contains "timeout"`:                  true,
+		`scope == ""`:                              true,
+		`!code == "W1042"`:                         false,
+		`!!(code == "W1042")`:                      true,
+		`!(line == 12) || code == "W1042"`:         true,
+		`line == 1 || line == 12 && code == "x"`:   false,
+		`(line == 1 || line == 12) && code != "x"`: true,
+	}
+
+	for src, want := range tests {
+		expr, err := warning.CompileExpr(src)
+		if err != nil {
+			t.Fatalf("%s: expected nil error, got %v", src, err)
+		}
+
+		if got := expr.Match(wrr); got != want {
+			t.Errorf("%s: expected %v, got %v", src, want, got)
+		}
+
+		if expr.String() != src {
+			t.Errorf("expected %v, got %v", src, expr.String())
+		}
+	}
+}
+
+func TestCompileExpr_Errors(t *testing.T) {
+	tests := []struct {
+		src    string
+		column int
+		msg    string
+	}{
+		{``, 1, "expected field or literal, found end of expression"},
+		{`code`, 5, "expected comparison operator, found end of expression"},
+		{`code = "W1"`, 6, `unexpected character '='`},
+		{`code == "W1`, 9, "unterminated string"},
+		{`code == "\q"`, 9, `invalid string "\q"`},
+		{`kode == "W1"`, 1, `unknown field "kode"`},
+		{`code == 1`, 6, "mismatched types string and int"},
+		{`severity >= 1`, 10, "mismatched types severity and int"},
+		{`code < "W1"`, 6, "operator < is not defined for string"},
+		{`tags == "x"`, 6, "mismatched types list and string"},
+		{`line ~ "1*"`, 6, "operator ~ needs a string or a list, found int"},
+		{`code ~ code`, 6, "operator ~ needs a string literal pattern"},
+		{`code ~ "W["`, 8, "syntax error in pattern"},
+		{`code matches "("`, 14, "missing closing )"},
+		{`line contains "1"`, 6, "operator contains needs a string or a list, found int"},
+		{`code contains 1`, 6, "operator contains needs a string, found int"},
+		{`(code == "W1"`, 14, `expected ")", found end of expression`},
+		{`code == "W1" line == 1`, 14, `unexpected "line"`},
+		{`code == "W1" && && line == 1`, 17, `expected field or literal, found "&&"`},
+	}
+
+	for _, tt := range tests {
+		_, err := warning.CompileExpr(tt.src)
+
+		var exprErr *warning.ExprError
+
+		if !errors.As(err, &exprErr) {
+			t.Fatalf("%s: expected expression error, got %v", tt.src, err)
+		}
+
+		if !errors.Is(err, warning.ErrInvalidExpr) {
+			t.Errorf("%s: expected %v, got %v", tt.src, warning.ErrInvalidExpr, err)
+		}
+
+		if exprErr.Column != tt.column {
+			t.Errorf("%s: expected column %v, got %v", tt.src, tt.column, exprErr.Column)
+		}
+
+		if !strings.Contains(exprErr.Msg, tt.msg) {
+			t.Errorf("%s: expected %q, got %q", tt.src, tt.msg, exprErr.Msg)
+		}
+	}
+}
+
+func TestMustCompileExpr(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("expected panic")
+		}
+	}()
+
+	warning.MustCompileExpr("code ==")
+}
+
+func TestFilterExpr_Error(t *testing.T) {
+	ctx := context.Background()
+
+	got, err := warning.FilterExpr(ctx, "code ==")
+	if !errors.Is(err, warning.ErrInvalidExpr) {
+		t.Errorf("expected %v, got %v", warning.ErrInvalidExpr, err)
+	}
+
+	if got != ctx {
+		t.Errorf("expected same context, got %v", got)
+	}
+}
+
+func TestNewExprReader(t *testing.T) {
+	reader := &mockReader{
+		[]mockReaderResult{
+			{warning.WithCode(warning.New("test-1"), "W1"), nil},
+			{warning.WithCode(warning.New("test-2"), "W2"), nil},
+			{warning.WithCode(warning.New("test-3"), "W1"), nil},
+		},
+	}
+
+	filtered, err := warning.NewExprReader(reader, `code == "W1"`)
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	wrrs, err := warning.ReadAll(filtered)
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	if len(wrrs) != 2 || wrrs[0].Warn() != "test-1" || wrrs[1].Warn() != "test-3" {
+		t.Errorf("expected test-1 and test-3, got %v", wrrs)
+	}
+
+	if _, err := warning.NewExprReader(reader, "code =="); !errors.Is(err, warning.ErrInvalidExpr) {
+		t.Errorf("expected %v, got %v", warning.ErrInvalidExpr, err)
+	}
+}
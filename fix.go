@@ -0,0 +1,389 @@
+package warning
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Edit replaces the text covered by Range with NewText. The file is named by Range.Start.Filename.
+// Positions are resolved using their line and column, or their byte offset when they have no line.
+// An edit without an End is an insertion at Start.
+type Edit struct {
+	Range   Range
+	NewText string
+}
+
+// Fix is a suggested fix for a warning: a message describing it and the edits implementing it.
+type Fix struct {
+	Message string
+	Edits   []Edit
+}
+
+type fixWarning struct {
+	wrr   Warning
+	fixes []Fix
+}
+
+// WithFixes returns a warning that suggests the given fixes. The first fix is the preferred one.
+func WithFixes(wrr Warning, fixes ...Fix) Warning {
+	return &fixWarning{wrr, fixes}
+}
+
+func (wrr *fixWarning) Warn() string {
+	return wrr.wrr.Warn()
+}
+
+func (wrr *fixWarning) Format(state fmt.State, verb rune) {
+	formatState(state, verb, wrr)
+}
+
+func (wrr *fixWarning) String() string {
+	return stringOf(wrr.wrr)
+}
+
+func (wrr *fixWarning) MarshalJSON() ([]byte, error) {
+	return json.Marshal(wrr.wrr)
+}
+
+func (wrr *fixWarning) Fixes() []Fix {
+	return wrr.fixes
+}
+
+func (wrr *fixWarning) Unwrap() Warning {
+	return wrr.wrr
+}
+
+// FixesOf returns the fixes suggested by the warning or any warning it wraps.
+func FixesOf(wrr Warning) []Fix {
+	found, ok := find[interface{ Fixes() []Fix }](wrr)
+	if !ok {
+		return nil
+	}
+
+	return found.Fixes()
+}
+
+// ApplyFixes applies the preferred fix of each warning and returns the patched content of every changed file.
+// Sources are loaded with src. Identical edits suggested by several warnings are applied once,
+// any other overlap is refused with [ErrOverlappingEdits].
+func ApplyFixes(wrrs []Warning, src SourceFunc) (map[string][]byte, error) {
+	files, err := collectEdits(wrrs, src)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string][]byte, len(files))
+
+	for name, file := range files {
+		result[name] = applyEdits(file.content, file.edits)
+	}
+
+	return result, nil
+}
+
+// DiffFixes applies the preferred fix of each warning like [ApplyFixes]
+// and returns the changes as a unified diff.
+func DiffFixes(wrrs []Warning, src SourceFunc) ([]byte, error) {
+	files, err := collectEdits(wrrs, src)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	var buf bytes.Buffer
+
+	for _, name := range names {
+		writeDiff(&buf, name, files[name].content, files[name].edits)
+	}
+
+	return buf.Bytes(), nil
+}
+
+type fileEdits struct {
+	content []byte
+	edits   []offsetEdit
+}
+
+type offsetEdit struct {
+	start, end int
+	text       string
+}
+
+func collectEdits(wrrs []Warning, src SourceFunc) (map[string]*fileEdits, error) {
+	files := make(map[string]*fileEdits)
+
+	for _, wrr := range wrrs {
+		fixes := FixesOf(wrr)
+		if len(fixes) == 0 {
+			continue
+		}
+
+		for _, edit := range fixes[0].Edits {
+			name := edit.Range.Start.Filename
+
+			file, ok := files[name]
+			if !ok {
+				content, err := src(name)
+				if err != nil {
+					return nil, fmt.Errorf("%w: %s: %w", ErrInvalidEdit, name, err)
+				}
+
+				file = &fileEdits{content: content}
+				files[name] = file
+			}
+
+			resolved, err := resolveEdit(file.content, edit)
+			if err != nil {
+				return nil, err
+			}
+
+			file.edits = append(file.edits, resolved)
+		}
+	}
+
+	for name, file := range files {
+		edits, err := sortEdits(file.edits)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+
+		file.edits = edits
+	}
+
+	return files, nil
+}
+
+func resolveEdit(content []byte, edit Edit) (offsetEdit, error) {
+	start, ok := offsetOf(content, edit.Range.Start)
+	if !ok {
+		return offsetEdit{}, fmt.Errorf("%w: %s", ErrInvalidEdit, edit.Range)
+	}
+
+	end := start
+
+	if edit.Range.End.IsValid() || edit.Range.End.Offset > 0 {
+		if end, ok = offsetOf(content, edit.Range.End); !ok || end < start {
+			return offsetEdit{}, fmt.Errorf("%w: %s", ErrInvalidEdit, edit.Range)
+		}
+	}
+
+	return offsetEdit{start, end, edit.NewText}, nil
+}
+
+// sortEdits orders edits by their position, drops duplicates and refuses overlapping ones.
+func sortEdits(edits []offsetEdit) ([]offsetEdit, error) {
+	slices.SortStableFunc(edits, func(a, b offsetEdit) int {
+		return cmp.Or(cmp.Compare(a.start, b.start), cmp.Compare(a.end, b.end))
+	})
+
+	edits = slices.Compact(edits)
+
+	for i := 1; i < len(edits); i++ {
+		prev, next := edits[i-1], edits[i]
+
+		if prev.end > next.start || prev.start == next.start {
+			return nil, fmt.Errorf("%w: bytes %d-%d and %d-%d", ErrOverlappingEdits, prev.start, prev.end, next.start, next.end)
+		}
+	}
+
+	return edits, nil
+}
+
+// applyEdits applies sorted, non-overlapping edits to content.
+func applyEdits(content []byte, edits []offsetEdit) []byte {
+	var buf bytes.Buffer
+
+	last := 0
+
+	for _, edit := range edits {
+		buf.Write(content[last:edit.start])
+		buf.WriteString(edit.text)
+		last = edit.end
+	}
+
+	buf.Write(content[last:])
+
+	return buf.Bytes()
+}
+
+const diffContext = 3
+
+// change replaces old lines starting at line index pos with new lines.
+type change struct {
+	pos      int
+	old, new []string
+}
+
+func writeDiff(buf *bytes.Buffer, name string, content []byte, edits []offsetEdit) {
+	lines := splitLines(content)
+	changes := lineChanges(content, lines, edits)
+
+	if len(changes) == 0 {
+		return
+	}
+
+	fmt.Fprintf(buf, "--- a/%s\n+++ b/%s\n", name, name)
+
+	delta := 0
+
+	for i := 0; i < len(changes); {
+		// grow the hunk while the context of consecutive changes overlaps
+		j := i + 1
+		for j < len(changes) && changes[j].pos-diffContext <= changes[j-1].pos+len(changes[j-1].old)+diffContext {
+			j++
+		}
+
+		first, last := changes[i], changes[j-1]
+		from := max(first.pos-diffContext, 0)
+		to := min(last.pos+len(last.old)+diffContext, len(lines))
+
+		var hunk bytes.Buffer
+
+		pos := from
+
+		for _, c := range changes[i:j] {
+			for ; pos < c.pos; pos++ {
+				writeDiffLine(&hunk, ' ', lines[pos])
+			}
+
+			for _, line := range c.old {
+				writeDiffLine(&hunk, '-', line)
+			}
+
+			for _, line := range c.new {
+				writeDiffLine(&hunk, '+', line)
+			}
+
+			pos += len(c.old)
+		}
+
+		for ; pos < to; pos++ {
+			writeDiffLine(&hunk, ' ', lines[pos])
+		}
+
+		fmt.Fprintf(buf, "@@ -%s +%s @@\n", hunkRange(from, to-from), hunkRange(from+delta, to-from+sumDelta(changes[i:j])))
+		buf.Write(hunk.Bytes())
+
+		delta += sumDelta(changes[i:j])
+		i = j
+	}
+}
+
+func sumDelta(changes []change) int {
+	delta := 0
+
+	for _, c := range changes {
+		delta += len(c.new) - len(c.old)
+	}
+
+	return delta
+}
+
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
+
+func writeDiffLine(buf *bytes.Buffer, prefix byte, line string) {
+	buf.WriteByte(prefix)
+	buf.WriteString(line)
+
+	if !strings.HasSuffix(line, "\n") {
+		buf.WriteString("\n\\ No newline at end of file\n")
+	}
+}
+
+// lineChanges converts byte edits into whole-line changes. Edits touching the same lines are merged.
+func lineChanges(content []byte, lines []string, edits []offsetEdit) []change {
+	starts := make([]int, len(lines)+1)
+	for i, line := range lines {
+		starts[i+1] = starts[i] + len(line)
+	}
+
+	// an offset at the end of content belongs to the last line unless the content ends with a newline
+	lastLine := len(lines)
+	if len(content) > 0 && content[len(content)-1] != '\n' {
+		lastLine--
+	}
+
+	lineOf := func(offset int) int {
+		i, _ := slices.BinarySearch(starts, offset+1)
+
+		return min(max(i-1, 0), lastLine)
+	}
+
+	var changes []change
+
+	for i := 0; i < len(edits); {
+		first := lineOf(edits[i].start)
+		last := lineOf(max(edits[i].end-1, edits[i].start))
+
+		j := i + 1
+		for j < len(edits) && lineOf(edits[j].start) <= last {
+			last = max(last, lineOf(max(edits[j].end-1, edits[j].start)))
+			j++
+		}
+
+		regionStart, regionEnd := starts[first], starts[min(last+1, len(lines))]
+
+		group := make([]offsetEdit, 0, j-i)
+		for _, edit := range edits[i:j] {
+			group = append(group, offsetEdit{edit.start - regionStart, edit.end - regionStart, edit.text})
+		}
+
+		old := content[regionStart:regionEnd]
+		patched := applyEdits(old, group)
+
+		if !bytes.Equal(old, patched) {
+			changes = append(changes, trimChange(change{first, lines[first:min(last+1, len(lines))], splitLines(patched)}))
+		}
+
+		i = j
+	}
+
+	return changes
+}
+
+// trimChange drops the leading and trailing lines that are left unchanged.
+func trimChange(c change) change {
+	for len(c.old) > 0 && len(c.new) > 0 && c.old[0] == c.new[0] {
+		c.pos++
+		c.old, c.new = c.old[1:], c.new[1:]
+	}
+
+	for len(c.old) > 0 && len(c.new) > 0 && c.old[len(c.old)-1] == c.new[len(c.new)-1] {
+		c.old, c.new = c.old[:len(c.old)-1], c.new[:len(c.new)-1]
+	}
+
+	return c
+}
+
+// splitLines splits content into lines, keeping the line terminators.
+func splitLines(content []byte) []string {
+	var lines []string
+
+	for len(content) > 0 {
+		i := bytes.IndexByte(content, '\n') + 1
+		if i == 0 {
+			i = len(content)
+		}
+
+		lines = append(lines, string(content[:i]))
+		content = content[i:]
+	}
+
+	return lines
+}
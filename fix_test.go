@@ -0,0 +1,202 @@
+package warning_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"go.wamod.dev/warning"
+)
+
+func renameKey(filename string, line, column int, from, to string) warning.Warning {
+	start := warning.Position{Filename: filename, Line: line, Column: column}
+	end := warning.Position{Filename: filename, Line: line, Column: column + len(from)}
+
+	return warning.WithFixes(
+		warning.WithRange(warning.New(fmt.Sprintf("unknown key %q", from)), warning.Range{Start: start, End: end}),
+		warning.Fix{
+			Message: fmt.Sprintf("rename key %q to %q", from, to),
+			Edits:   []warning.Edit{{Range: warning.Range{Start: start, End: end}, NewText: to}},
+		},
+	)
+}
+
+// ExampleDiffFixes demonstrates how to turn suggested fixes into a unified diff.
+func ExampleDiffFixes() {
+	src := mockSource(map[string]string{
+		"config.toml": "[server]\nhost = \"localhost\"\ntiemout = \"5s\"\n",
+	})
+
+	wrrs := []warning.Warning{
+		renameKey("config.toml", 3, 1, "tiemout", "timeout"),
+	}
+
+	diff, err := warning.DiffFixes(wrrs, src)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Print(string(diff))
+
+	// Output:
+	// --- a/config.toml
+	// +++ b/config.toml
+	// @@ -1,3 +1,3 @@
+	//  [server]
+	//  host = "localhost"
+	// -tiemout = "5s"
+	// +timeout = "5s"
+}
+
+func TestWithFixes(t *testing.T) {
+	inner := warning.New("test")
+	fix := warning.Fix{Message: "fix it"}
+	wrr := warning.WithFixes(inner, fix)
+
+	if wrr.Warn() != "test" {
+		t.Errorf("expected test, got %v", wrr.Warn())
+	}
+
+	if got := warning.Unwrap(wrr); got != inner {
+		t.Errorf("expected %v, got %v", inner, got)
+	}
+
+	if got := warning.FixesOf(warning.WithPath(wrr, nil)); len(got) != 1 || got[0].Message != fix.Message {
+		t.Errorf("expected %v, got %v", fix, got)
+	}
+
+	if got := warning.FixesOf(inner); got != nil {
+		t.Errorf("expected no fixes, got %v", got)
+	}
+
+	requireForwarded(t, wrr, "test")
+}
+
+func TestApplyFixes(t *testing.T) {
+	src := mockSource(map[string]string{
+		"a.conf": "naem = 1\nvalu = 2\n",
+		"b.conf": "abc",
+	})
+
+	wrrs := []warning.Warning{
+		renameKey("a.conf", 1, 1, "naem", "name"),
+		renameKey("a.conf", 2, 1, "valu", "value"),
+		renameKey("a.conf", 2, 1, "valu", "value"), // duplicates are applied once
+		warning.New("no fix"),
+		warning.WithFixes(warning.New("insert"), warning.Fix{
+			Edits: []warning.Edit{{
+				Range:   warning.Range{Start: warning.Position{Filename: "b.conf", Offset: 3}},
+				NewText: "def",
+			}},
+		}, warning.Fix{Message: "ignored alternative"}),
+	}
+
+	got, err := warning.ApplyFixes(wrrs, src)
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	want := map[string]string{
+		"a.conf": "name = 1\nvalue = 2\n",
+		"b.conf": "abcdef",
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("expected %v files, got %v", len(want), len(got))
+	}
+
+	for name, content := range want {
+		if string(got[name]) != content {
+			t.Errorf("%s: expected %q, got %q", name, content, got[name])
+		}
+	}
+}
+
+func TestApplyFixes_Overlapping(t *testing.T) {
+	src := mockSource(map[string]string{
+		"a.conf": "naem = 1\n",
+	})
+
+	wrrs := []warning.Warning{
+		renameKey("a.conf", 1, 1, "naem", "name"),
+		renameKey("a.conf", 1, 3, "em = 1", "me = 2"),
+	}
+
+	if _, err := warning.ApplyFixes(wrrs, src); !errors.Is(err, warning.ErrOverlappingEdits) {
+		t.Fatalf("expected %v, got %v", warning.ErrOverlappingEdits, err)
+	}
+
+	if _, err := warning.DiffFixes(wrrs, src); !errors.Is(err, warning.ErrOverlappingEdits) {
+		t.Fatalf("expected %v, got %v", warning.ErrOverlappingEdits, err)
+	}
+}
+
+func TestApplyFixes_Invalid(t *testing.T) {
+	src := mockSource(map[string]string{
+		"a.conf": "naem = 1\n",
+	})
+
+	tests := []warning.Warning{
+		renameKey("a.conf", 5, 1, "naem", "name"),
+		renameKey("missing.conf", 1, 1, "naem", "name"),
+	}
+
+	for _, wrr := range tests {
+		if _, err := warning.ApplyFixes([]warning.Warning{wrr}, src); !errors.Is(err, warning.ErrInvalidEdit) {
+			t.Errorf("expected %v, got %v", warning.ErrInvalidEdit, err)
+		}
+	}
+}
+
+func TestDiffFixes(t *testing.T) {
+	src := mockSource(map[string]string{
+		"a.conf": "l1\nl2\nl3\nl4\nl5\nl6\nl7\nl8\nl9\nl10\nl11\nl12\nl13\nl14\nl15\nl16",
+	})
+
+	insert := func(line int, text string) warning.Warning {
+		return warning.WithFixes(warning.New("test"), warning.Fix{
+			Edits: []warning.Edit{{
+				Range:   warning.Range{Start: warning.Position{Filename: "a.conf", Line: line, Column: 1}},
+				NewText: text,
+			}},
+		})
+	}
+
+	wrrs := []warning.Warning{
+		insert(2, "new\n"),
+		renameKey("a.conf", 5, 1, "l5", "L5"),
+		renameKey("a.conf", 16, 1, "l16", "L16"),
+	}
+
+	got, err := warning.DiffFixes(wrrs, src)
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	want := `--- a/a.conf
++++ b/a.conf
+@@ -1,8 +1,9 @@
+ l1
++new
+ l2
+ l3
+ l4
+-l5
++L5
+ l6
+ l7
+ l8
+@@ -13,4 +14,4 @@
+ l13
+ l14
+ l15
+-l16
+\ No newline at end of file
++L16
+\ No newline at end of file
+`
+
+	if string(got) != want {
+		t.Errorf("expected:\n%s\ngot:\n%s", want, got)
+	}
+}
@@ -0,0 +1,117 @@
+package warning
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// FormatOptions controls how [Format] renders a warning.
+type FormatOptions struct {
+	// Verbose adds the code, severity, attributes, location, scope, hint, notes, suggested fixes
+	// and messages of wrapped warnings, each on its own indented line below the message.
+	Verbose bool
+}
+
+// Format returns the message of the warning. With opts.Verbose, the details of the warning are
+// rendered on separate lines, omitting the ones that are not set:
+//
+//	unknown key "tiemout"
+//	    code: W1042
+//	    severity: warning
+//	    attrs: request_id=req-1
+//	    location: config.toml:12:5
+//	    scope: config
+//	    hint: did you mean "timeout"?
+//	    note: config.toml:3:1: timeout is defined here
+//	    fix: rename to timeout
+//	    wraps: invalid configuration
+//
+// Format works with any warning, built-in warning types use it to implement [fmt.Formatter]
+// so that they can be printed with the %+v verb.
+func Format(wrr Warning, opts FormatOptions) string {
+	var buf strings.Builder
+
+	msg := wrr.Warn()
+	buf.WriteString(msg)
+
+	if !opts.Verbose {
+		return buf.String()
+	}
+
+	line := func(key, value string) {
+		if value != "" {
+			buf.WriteString("\n    ")
+			buf.WriteString(key)
+			buf.WriteString(": ")
+			buf.WriteString(value)
+		}
+	}
+
+	line("code", CodeOf(wrr))
+	line("severity", SeverityOf(wrr).String())
+
+	var attrs []string
+	for _, attr := range AttrsOf(wrr) {
+		attrs = append(attrs, fmt.Sprintf("%s=%v", attr.Key, attr.Value))
+	}
+
+	line("attrs", strings.Join(attrs, " "))
+
+	if rng, ok := RangeOf(wrr); ok {
+		line("location", rng.String())
+	}
+
+	if path, ok := PathOf(wrr); ok {
+		line("path", path.String())
+	}
+
+	line("scope", ScopeOf(wrr))
+
+	var tags []string
+	for _, tag := range TagsOf(wrr) {
+		tags = append(tags, string(tag))
+	}
+
+	line("tags", strings.Join(tags, " "))
+	line("hint", HintOf(wrr))
+
+	for _, note := range NotesOf(wrr) {
+		line("note", noteText(note))
+	}
+
+	for _, fix := range FixesOf(wrr) {
+		line("fix", fix.Message)
+	}
+
+	// decorators repeat the message of the warning they wrap, only list messages that differ
+	for inner := Unwrap(wrr); inner != nil; inner = Unwrap(inner) {
+		if innerMsg := inner.Warn(); innerMsg != msg {
+			line("wraps", innerMsg)
+			msg = innerMsg
+		}
+	}
+
+	return buf.String()
+}
+
+// noteText returns the message of the note, preceded by its location if it has one.
+func noteText(note Note) string {
+	if note.Range.Start.IsValid() || note.Range.Start.Filename != "" {
+		return note.Range.String() + ": " + note.Message
+	}
+
+	return note.Message
+}
+
+// formatState implements [fmt.Formatter] for built-in warning types. The %+v verb renders the
+// warning using [Format] in verbose mode, other verbs format the message as a string.
+func formatState(state fmt.State, verb rune, wrr Warning) {
+	if verb == 'v' && state.Flag('+') {
+		_, _ = io.WriteString(state, Format(wrr, FormatOptions{Verbose: true}))
+
+		return
+	}
+
+	_, _ = fmt.Fprintf(state, fmt.FormatString(state, verb), wrr.Warn())
+}
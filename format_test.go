@@ -0,0 +1,101 @@
+package warning_test
+
+import (
+	"fmt"
+	"go/token"
+	"testing"
+
+	"go.wamod.dev/warning"
+)
+
+// ExampleFormat demonstrates how to print the details of a warning.
+func ExampleFormat() {
+	wrr := warning.New("unknown key \"tiemout\"")
+	wrr = warning.WithPosition(wrr, token.Position{Filename: "config.toml", Line: 12, Column: 5})
+	wrr = warning.WithCode(wrr, "W1042")
+	wrr = warning.WithAttrs(wrr, warning.Attr{Key: "request_id", Value: "req-1"})
+
+	fmt.Printf("%v\n", wrr)
+	fmt.Printf("%+v\n", wrr)
+
+	// Output:
+	// unknown key "tiemout"
+	// unknown key "tiemout"
+	//     code: W1042
+	//     severity: warning
+	//     attrs: request_id=req-1
+	//     location: config.toml:12:5
+}
+
+type customWarning struct {
+	msg   string
+	inner warning.Warning
+}
+
+func (wrr *customWarning) Warn() string {
+	return wrr.msg
+}
+
+func (wrr *customWarning) Unwrap() warning.Warning {
+	return wrr.inner
+}
+
+func TestFormat(t *testing.T) {
+	inner := warning.WithTags(warning.New("connection reset"), warning.TagDeprecation)
+	wrr := warning.Warning(&customWarning{"query failed", inner})
+	wrr = warning.WithPath(wrr, warning.Path{}.Field("db").Index(1))
+	wrr = warning.WithSeverity(wrr, warning.SeverityError)
+	wrr = warning.WithHint(wrr, "retry later")
+	wrr = warning.WithNotes(wrr,
+		warning.Note{Message: "pool configured here", Range: warning.Range{Start: token.Position{Filename: "db.toml", Line: 4, Column: 1}}},
+		warning.Note{Message: "see the pool documentation"},
+	)
+	wrr = warning.WithFixes(wrr, warning.Fix{Message: "increase the timeout"})
+	wrr = scoped("db", wrr)
+
+	want := "query failed\n" +
+		"    severity: error\n" +
+		"    path: db[1]\n" +
+		"    scope: db\n" +
+		"    tags: deprecation\n" +
+		"    hint: retry later\n" +
+		"    note: db.toml:4:1: pool configured here\n" +
+		"    note: see the pool documentation\n" +
+		"    fix: increase the timeout\n" +
+		"    wraps: connection reset"
+
+	if got := warning.Format(wrr, warning.FormatOptions{Verbose: true}); got != want {
+		t.Errorf("expected:\n%s\ngot:\n%s", want, got)
+	}
+
+	if got := warning.Format(wrr, warning.FormatOptions{}); got != "query failed" {
+		t.Errorf("expected %q, got %q", "query failed", got)
+	}
+}
+
+func TestFormatter(t *testing.T) {
+	wrr := warning.WithCode(warning.New("test"), "W1")
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"%v", "test"},
+		{"%s", "test"},
+		{"%q", `"test"`},
+		{"%6s|", "  test|"},
+		{"%+v", "test\n    code: W1\n    severity: warning"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			if got := fmt.Sprintf(tt.format, wrr); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+
+	if got := fmt.Sprintf("%+v", warning.New("test")); got != "test\n    severity: warning" {
+		t.Errorf("expected verbose format, got %q", got)
+	}
+}
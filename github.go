@@ -0,0 +1,113 @@
+package warning
+
+import (
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+type githubWriter struct {
+	w   io.Writer
+	mtx sync.Mutex
+}
+
+// NewGitHubWriter returns a Writer that writes each warning as a GitHub Actions workflow command,
+// so that it is shown as an annotation on the pull request when written to the standard output of a job:
+//
+//	ctx = warning.Attach(ctx, warning.NewGitHubWriter(os.Stdout))
+//
+// Errors are written as error commands, warnings as warning commands and other severities as notice commands:
+//
+//	::warning file=config.toml,line=12,col=5,title=W1042: Unknown key::unknown key "tiemout"
+//
+// The title is the code of the warning, followed by the title of its [Definition] if one is registered.
+// The notes and the hint of the warning are added to the message on separate lines.
+func NewGitHubWriter(w io.Writer) Writer {
+	return &githubWriter{w: w}
+}
+
+func (writer *githubWriter) WriteWarning(wrr Warning) error {
+	var props []string
+
+	prop := func(key, value string) {
+		props = append(props, key+"="+escapeGitHubProperty(value))
+	}
+
+	if rng, ok := RangeOf(wrr); ok && rng.Start.Filename != "" {
+		prop("file", filepath.ToSlash(rng.Start.Filename))
+
+		if rng.Start.Line > 0 {
+			prop("line", strconv.Itoa(rng.Start.Line))
+
+			if rng.End.Line > 0 {
+				prop("endLine", strconv.Itoa(rng.End.Line))
+			}
+
+			if rng.Start.Column > 0 {
+				prop("col", strconv.Itoa(rng.Start.Column))
+			}
+
+			if rng.End.Column > 0 {
+				prop("endColumn", strconv.Itoa(rng.End.Column))
+			}
+		}
+	}
+
+	if code := CodeOf(wrr); code != "" {
+		title := code
+		if def, ok := DefinitionOf(wrr); ok && def.Title != "" {
+			title += ": " + def.Title
+		}
+
+		prop("title", title)
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString("::")
+	sb.WriteString(githubCommand(SeverityOf(wrr)))
+
+	if len(props) > 0 {
+		sb.WriteByte(' ')
+		sb.WriteString(strings.Join(props, ","))
+	}
+
+	sb.WriteString("::")
+	sb.WriteString(escapeGitHubData(reportMessage(wrr)))
+	sb.WriteByte('\n')
+
+	writer.mtx.Lock()
+	defer writer.mtx.Unlock()
+
+	_, err := io.WriteString(writer.w, sb.String())
+
+	return err
+}
+
+func githubCommand(severity Severity) string {
+	switch {
+	case severity >= SeverityError:
+		return "error"
+	case severity == SeverityWarning:
+		return "warning"
+	default:
+		return "notice"
+	}
+}
+
+var (
+	githubDataEscaper     = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A")                         //nolint:gochecknoglobals
+	githubPropertyEscaper = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C") //nolint:gochecknoglobals
+)
+
+// escapeGitHubData escapes the message of a workflow command.
+func escapeGitHubData(s string) string {
+	return githubDataEscaper.Replace(s)
+}
+
+// escapeGitHubProperty escapes a property value of a workflow command.
+func escapeGitHubProperty(s string) string {
+	return githubPropertyEscaper.Replace(s)
+}
@@ -0,0 +1,58 @@
+package warning_test
+
+import (
+	"context"
+	"go/token"
+	"os"
+	"strings"
+	"testing"
+
+	"go.wamod.dev/warning"
+)
+
+// ExampleNewGitHubWriter demonstrates how to report warnings as GitHub Actions annotations.
+func ExampleNewGitHubWriter() {
+	ctx := warning.Attach(context.Background(), warning.NewGitHubWriter(os.Stdout))
+
+	warning.Warn(ctx,
+		warning.WithPosition(warning.WithCode(warning.New(`unknown key "tiemout"`), "W1042"),
+			token.Position{Filename: "config.toml", Line: 12, Column: 5}),
+		warning.WithSeverity(warning.New("build is slow"), warning.SeverityInfo),
+	)
+
+	// Output:
+	// ::warning file=config.toml,line=12,col=5,title=W1042::unknown key "tiemout"
+	// ::notice::build is slow
+}
+
+func TestGitHubWriter(t *testing.T) {
+	var buf strings.Builder
+
+	writer := warning.NewGitHubWriter(&buf)
+
+	wrr := warning.New("100% wrong\r\nsecond line: a, b")
+	wrr = warning.WithRange(wrr, warning.Range{
+		Start: token.Position{Filename: "dir/a,b:c.go", Line: 1, Column: 2},
+		End:   token.Position{Filename: "dir/a,b:c.go", Line: 3, Column: 4},
+	})
+	wrr = warning.WithNotes(wrr, warning.Note{Message: "defined here", Range: warning.Range{
+		Start: token.Position{Filename: "b.go", Line: 5, Column: 1},
+	}})
+	wrr = warning.WithHint(warning.WithSeverity(wrr, warning.SeverityError), "fix it")
+
+	if err := writer.WriteWarning(wrr); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	if err := writer.WriteWarning(sarifDefinition.New("test")); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	want := "::error file=dir/a%2Cb%3Ac.go,line=1,endLine=3,col=2,endColumn=4::" +
+		"100%25 wrong%0D%0Asecond line: a, b%0Anote: b.go:5:1: defined here%0Ahint: fix it\n" +
+		"::error title=SARIF1%3A Unknown key::test\n"
+
+	if buf.String() != want {
+		t.Errorf("expected:\n%q\ngot:\n%q", want, buf.String())
+	}
+}
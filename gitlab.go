@@ -0,0 +1,86 @@
+package warning
+
+import (
+	"encoding/json"
+	"io"
+	"path/filepath"
+)
+
+type gitlabIssue struct {
+	Description string         `json:"description"`
+	CheckName   string         `json:"check_name"`
+	Fingerprint string         `json:"fingerprint"`
+	Severity    string         `json:"severity"`
+	Location    gitlabLocation `json:"location"`
+}
+
+type gitlabLocation struct {
+	Path  string      `json:"path"`
+	Lines gitlabLines `json:"lines"`
+}
+
+type gitlabLines struct {
+	Begin int `json:"begin"`
+	End   int `json:"end,omitempty"`
+}
+
+// EncodeGitLab writes the warnings read from r to w as a GitLab Code Quality report: a JSON array
+// of issues, each with a description, check name, fingerprint, severity and location.
+// The check name is the code of the warning, or "warning" if it has none. Severities map to
+// major, minor and info, and notes and the hint are written on separate lines of the description.
+//
+// The format requires a file and a line for each issue. Warnings without a file are left out,
+// warnings without a line are reported on the first line of their file.
+func EncodeGitLab(w io.Writer, r Reader) error {
+	wrrs, err := ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	issues := make([]gitlabIssue, 0, len(wrrs))
+	prints := fingerprints(wrrs)
+
+	for i, wrr := range wrrs {
+		rng, ok := RangeOf(wrr)
+		if !ok || rng.Start.Filename == "" {
+			continue
+		}
+
+		issue := gitlabIssue{
+			Description: reportMessage(wrr),
+			CheckName:   CodeOf(wrr),
+			Fingerprint: prints[i],
+			Severity:    gitlabSeverity(SeverityOf(wrr)),
+			Location: gitlabLocation{
+				Path:  filepath.ToSlash(rng.Start.Filename),
+				Lines: gitlabLines{Begin: max(rng.Start.Line, 1)},
+			},
+		}
+
+		if issue.CheckName == "" {
+			issue.CheckName = "warning"
+		}
+
+		if rng.End.Line > rng.Start.Line {
+			issue.Location.Lines.End = rng.End.Line
+		}
+
+		issues = append(issues, issue)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(issues)
+}
+
+func gitlabSeverity(severity Severity) string {
+	switch {
+	case severity >= SeverityError:
+		return "major"
+	case severity == SeverityWarning:
+		return "minor"
+	default:
+		return "info"
+	}
+}
@@ -0,0 +1,67 @@
+package warning_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"go/token"
+	"testing"
+
+	"go.wamod.dev/warning"
+)
+
+func TestEncodeGitLab(t *testing.T) {
+	var buf bytes.Buffer
+
+	wrrs := append(reportWarnings(),
+		warning.WithSeverity(warning.WithHint(warning.WithPosition(warning.New("unused value"),
+			token.Position{Filename: "values.yaml"}), "remove it"), warning.SeverityInfo),
+	)
+
+	if err := warning.EncodeGitLab(&buf, warning.NewSliceReader(wrrs)); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	var issues []map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &issues); err != nil {
+		t.Fatalf("expected valid JSON, got %v", err)
+	}
+
+	// warnings without a file are left out
+	if len(issues) != 3 {
+		t.Fatalf("expected 3 issues, got %d", len(issues))
+	}
+
+	fingerprints := make(map[any]bool)
+
+	for _, issue := range issues {
+		requireFields(t, issue, "issue", "description", "check_name", "fingerprint", "severity", "location")
+
+		location := issue["location"].(map[string]any)
+		requireFields(t, location, "location", "path", "lines")
+
+		if begin := location["lines"].(map[string]any)["begin"]; begin.(float64) < 1 {
+			t.Errorf("expected begin line to be positive, got %v", begin)
+		}
+
+		if fingerprints[issue["fingerprint"]] {
+			t.Errorf("expected unique fingerprints, got %v twice", issue["fingerprint"])
+		}
+
+		fingerprints[issue["fingerprint"]] = true
+	}
+
+	first := issues[0]
+	if first["check_name"] != "SARIF1" || first["severity"] != "major" ||
+		first["description"] != "unknown key \"tiemout\"\nnote: config/app.toml:3:1: defined here" {
+		t.Errorf("unexpected first issue %v", first)
+	}
+
+	if path := first["location"].(map[string]any)["path"]; path != "config/app.toml" {
+		t.Errorf("expected path config/app.toml, got %v", path)
+	}
+
+	if third := issues[2]; third["check_name"] != "warning" || third["severity"] != "info" ||
+		third["description"] != "unused value\nhint: remove it" {
+		t.Errorf("unexpected third issue %v", third)
+	}
+}
@@ -5,6 +5,8 @@ import (
 )
 
 // Map returns a new context that transforms each written warning using the provided function.
+// Attached data such as notes, hints or positions is preserved when mapFunc wraps the warning
+// it receives rather than replacing it with a new one.
 func Map(ctx context.Context, mapFunc func(wrr Warning) Warning) context.Context {
 	writer := getWriter(ctx)
 	if writer == nil {
@@ -20,7 +22,11 @@ type mapWriter struct {
 }
 
 func (writer *mapWriter) WriteWarning(wrr Warning) error {
-	return writer.next.WriteWarning(writer.mapFunc(wrr))
+	return writer.WriteWarningContext(context.Background(), wrr)
+}
+
+func (writer *mapWriter) WriteWarningContext(ctx context.Context, wrr Warning) error {
+	return writeWarning(ctx, writer.next, writer.mapFunc(wrr))
 }
 
 // Filter returns a new context that filters written warnings using the provided function.
@@ -39,8 +45,12 @@ type filterWriter struct {
 }
 
 func (writer *filterWriter) WriteWarning(wrr Warning) error {
+	return writer.WriteWarningContext(context.Background(), wrr)
+}
+
+func (writer *filterWriter) WriteWarningContext(ctx context.Context, wrr Warning) error {
 	if writer.filterFunc(wrr) {
-		return writer.next.WriteWarning(wrr)
+		return writeWarning(ctx, writer.next, wrr)
 	}
 
 	return nil
@@ -71,7 +81,7 @@ func Reduce[T Warning](ctx context.Context, reduceFunc func(acc T, wrr Warning)
 			acc = reduceFunc(acc, wrr)
 		}
 
-		_ = writer.WriteWarning(acc)
+		_ = writeWarning(ctx, writer, acc)
 	}
 }
 
@@ -92,7 +102,11 @@ type tapWriter struct {
 }
 
 func (writer *tapWriter) WriteWarning(wrr Warning) error {
+	return writer.WriteWarningContext(context.Background(), wrr)
+}
+
+func (writer *tapWriter) WriteWarningContext(ctx context.Context, wrr Warning) error {
 	writer.tapFunc(wrr)
 
-	return writer.next.WriteWarning(wrr)
+	return writeWarning(ctx, writer.next, wrr)
 }
@@ -180,6 +180,30 @@ func TestMap(t *testing.T) {
 	}
 }
 
+func TestMapPassThrough(t *testing.T) {
+	writer := &mockWriter{}
+	notes := []warning.Note{{Message: "note"}}
+
+	ctx := warning.Attach(context.Background(), writer)
+	ctx = warning.Map(ctx, func(wrr warning.Warning) warning.Warning {
+		return warning.WithPath(wrr, warning.Path{}.Field("name"))
+	})
+
+	warning.Warn(ctx, warning.WithHint(warning.WithNotes(warning.New("test"), notes...), "hint"))
+
+	if len(writer.buf) != 1 {
+		t.Fatalf("expected 1 warning, got %v", len(writer.buf))
+	}
+
+	if got := warning.NotesOf(writer.buf[0]); len(got) != 1 || got[0] != notes[0] {
+		t.Errorf("expected %v, got %v", notes, got)
+	}
+
+	if got := warning.HintOf(writer.buf[0]); got != "hint" {
+		t.Errorf("expected hint, got %v", got)
+	}
+}
+
 func TestMapNoWriter(t *testing.T) {
 	ctx := warning.Map(context.Background(), func(wrr warning.Warning) warning.Warning {
 		return warning.New(strings.ToUpper(wrr.Warn()))
@@ -216,6 +240,22 @@ func TestFilter(t *testing.T) {
 	}
 }
 
+func TestFilterPassThrough(t *testing.T) {
+	writer := &mockWriter{}
+	want := warning.WithHint(warning.WithNotes(warning.New("test"), warning.Note{Message: "note"}), "hint")
+
+	ctx := warning.Attach(context.Background(), writer)
+	ctx = warning.Filter(ctx, func(wrr warning.Warning) bool {
+		return warning.HintOf(wrr) != ""
+	})
+
+	warning.Warn(ctx, want, warning.New("no hint"))
+
+	if len(writer.buf) != 1 || writer.buf[0] != want {
+		t.Fatalf("expected %v, got %v", want, writer.buf)
+	}
+}
+
 func TestFilterNoWriter(t *testing.T) {
 	ctx := warning.Filter(context.Background(), func(wrr warning.Warning) bool {
 		return wrr.Warn() != "ignore"
@@ -0,0 +1,194 @@
+package warning
+
+import (
+	"html/template"
+	"io"
+	"slices"
+)
+
+// HTMLOptions controls how [EncodeHTML] renders warnings.
+type HTMLOptions struct {
+	// Title is the title of the page. It defaults to "Warnings".
+	Title string
+}
+
+type htmlReport struct {
+	Title      string
+	Summary    string
+	Severities []string
+	Codes      []string
+	Rows       []htmlRow
+}
+
+type htmlRow struct {
+	Index    int
+	Severity Severity
+	Code     string
+	// Group is the code, or the placeholder used for warnings without a code in the code filter.
+	Group    string
+	URL      string
+	Location string
+	Message  string
+	Hint     string
+	Notes    []string
+}
+
+// EncodeHTML writes the warnings read from r to w as a self-contained HTML page, meant to be published
+// as a nightly report. The page lists the warnings in a table that can be sorted by any column and
+// filtered by severity and code. Styles and scripts are inlined, so the page loads no external assets.
+func EncodeHTML(w io.Writer, r Reader, opts HTMLOptions) error {
+	wrrs, err := ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	report := htmlReport{Title: opts.Title, Summary: textSummary(wrrs)}
+	if report.Title == "" {
+		report.Title = "Warnings"
+	}
+
+	var severities []Severity
+
+	for _, group := range groupWarnings(wrrs, GroupByCode) {
+		report.Codes = append(report.Codes, groupName(group.key, GroupByCode))
+	}
+
+	for i, wrr := range wrrs {
+		row := htmlRow{
+			Index:    i + 1,
+			Severity: SeverityOf(wrr),
+			Code:     CodeOf(wrr),
+			Group:    groupName(CodeOf(wrr), GroupByCode),
+			Location: locationOf(wrr),
+			Message:  wrr.Warn(),
+			Hint:     HintOf(wrr),
+		}
+
+		if def, ok := DefinitionOf(wrr); ok {
+			row.URL = def.URL
+		}
+
+		for _, note := range NotesOf(wrr) {
+			row.Notes = append(row.Notes, noteText(note))
+		}
+
+		if !slices.Contains(severities, row.Severity) {
+			severities = append(severities, row.Severity)
+		}
+
+		report.Rows = append(report.Rows, row)
+	}
+
+	// most important first
+	slices.Sort(severities)
+	slices.Reverse(severities)
+
+	for _, severity := range severities {
+		report.Severities = append(report.Severities, severity.String())
+	}
+
+	return htmlTemplate.Execute(w, report)
+}
+
+//nolint:gochecknoglobals
+var htmlTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+body { font-family: system-ui, sans-serif; margin: 2rem; color: #1f2328; }
+table { border-collapse: collapse; width: 100%; }
+th, td { border-bottom: 1px solid #d0d7de; padding: 0.4rem 0.6rem; text-align: left; vertical-align: top; }
+th { cursor: pointer; user-select: none; background: #f6f8fa; }
+th[aria-sort=ascending]::after { content: " \25B2"; }
+th[aria-sort=descending]::after { content: " \25BC"; }
+td.location, td.code { font-family: ui-monospace, monospace; white-space: nowrap; }
+.severity { font-weight: 600; }
+.severity-error { color: #cf222e; }
+.severity-warning { color: #9a6700; }
+.severity-info { color: #0969da; }
+.severity-hint { color: #59636e; }
+.hint, .note { color: #59636e; margin-top: 0.2rem; }
+.filters { display: flex; gap: 1rem; margin: 1rem 0; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<p>{{.Summary}}</p>
+{{- if .Rows}}
+<div class="filters">
+<label>Severity <select id="severity"><option value="">all</option>
+{{- range .Severities}}<option>{{.}}</option>{{end -}}
+</select></label>
+<label>Code <select id="code"><option value="">all</option>
+{{- range .Codes}}<option>{{.}}</option>{{end -}}
+</select></label>
+<span id="count"></span>
+</div>
+<table id="warnings">
+<thead><tr><th data-type="number">#</th><th data-type="number">Severity</th><th>Code</th><th>Location</th><th>Message</th></tr></thead>
+<tbody>
+{{- range .Rows}}
+<tr data-severity="{{.Severity}}" data-code="{{.Group}}">
+<td>{{.Index}}</td>
+<td class="severity severity-{{.Severity}}" data-value="{{printf "%d" .Severity}}">{{.Severity}}</td>
+<td class="code">{{if .URL}}<a href="{{.URL}}">{{.Code}}</a>{{else}}{{.Code}}{{end}}</td>
+<td class="location">{{.Location}}</td>
+<td>{{.Message}}
+{{- range .Notes}}<div class="note">note: {{.}}</div>{{end}}
+{{- if .Hint}}<div class="hint">hint: {{.Hint}}</div>{{end -}}
+</td>
+</tr>
+{{- end}}
+</tbody>
+</table>
+<script>
+(() => {
+  const table = document.getElementById("warnings");
+  const body = table.tBodies[0];
+  const severity = document.getElementById("severity");
+  const code = document.getElementById("code");
+  const count = document.getElementById("count");
+
+  const filter = () => {
+    let shown = 0;
+    for (const row of body.rows) {
+      row.hidden = (severity.value !== "" && row.dataset.severity !== severity.value) ||
+        (code.value !== "" && row.dataset.code !== code.value);
+      if (!row.hidden) shown++;
+    }
+    count.textContent = shown + " of " + body.rows.length + " shown";
+  };
+
+  const value = (row, column) => {
+    const cell = row.cells[column];
+    return cell.dataset.value !== undefined ? cell.dataset.value : cell.textContent.trim();
+  };
+
+  for (const [column, header] of Array.from(table.tHead.rows[0].cells).entries()) {
+    header.addEventListener("click", () => {
+      const ascending = header.getAttribute("aria-sort") !== "ascending";
+      const numeric = header.dataset.type === "number";
+      for (const other of table.tHead.rows[0].cells) other.removeAttribute("aria-sort");
+      header.setAttribute("aria-sort", ascending ? "ascending" : "descending");
+
+      const rows = Array.from(body.rows).sort((a, b) => {
+        const x = value(a, column), y = value(b, column);
+        const order = numeric ? x - y : x.localeCompare(y, undefined, {numeric: true});
+        return ascending ? order : -order;
+      });
+      body.append(...rows);
+    });
+  }
+
+  severity.addEventListener("change", filter);
+  code.addEventListener("change", filter);
+  filter();
+})();
+</script>
+{{- end}}
+</body>
+</html>
+`))
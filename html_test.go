@@ -0,0 +1,74 @@
+package warning_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"go.wamod.dev/warning"
+)
+
+func TestEncodeHTML(t *testing.T) {
+	var buf bytes.Buffer
+
+	wrrs := append(reportWarnings(), warning.WithHint(warning.New("<script>alert(1)</script>"), "remove it"))
+
+	if err := warning.EncodeHTML(&buf, warning.NewSliceReader(wrrs), warning.HTMLOptions{Title: "Nightly"}); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	got := buf.String()
+
+	for _, want := range []string{
+		"<title>Nightly</title>",
+		"<p>6 warnings, 3 errors in 2 files</p>",
+		`<select id="severity"><option value="">all</option><option>error</option><option>warning</option><option>info</option></select>`,
+		`<select id="code"><option value="">all</option><option>SARIF1</option><option>W2</option><option>(no code)</option></select>`,
+		`<tr data-severity="error" data-code="SARIF1">`,
+		`<tr data-severity="info" data-code="(no code)">`,
+		`<td class="severity severity-error" data-value="1">error</td>`,
+		`<td class="code"><a href="https://example.com/SARIF1">SARIF1</a></td>`,
+		`<td class="location">config/app.toml:3:1-3:8</td>`,
+		`<div class="note">note: config/app.toml:3:1: defined here</div>`,
+		`<div class="hint">hint: remove it</div>`,
+		"&lt;script&gt;alert(1)&lt;/script&gt;",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected output to contain %s", want)
+		}
+	}
+
+	if n := strings.Count(got, "<tr data-severity="); n != len(wrrs) {
+		t.Errorf("expected %d rows, got %d", len(wrrs), n)
+	}
+
+	// the page must not load external assets
+	for _, asset := range []string{"<link", " src="} {
+		if strings.Contains(got, asset) {
+			t.Errorf("expected no external assets, found %s", asset)
+		}
+	}
+}
+
+func TestEncodeHTML_Empty(t *testing.T) {
+	var buf bytes.Buffer
+
+	if err := warning.EncodeHTML(&buf, warning.NewSliceReader(nil), warning.HTMLOptions{}); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	if got := buf.String(); !strings.Contains(got, "<p>no warnings</p>") || strings.Contains(got, "<table") {
+		t.Errorf("expected a page without a table, got %s", got)
+	}
+}
+
+func TestEncodeHTML_ReadError(t *testing.T) {
+	collector := warning.NewCollector()
+	collector.Close()
+
+	err := warning.EncodeHTML(&bytes.Buffer{}, collector, warning.HTMLOptions{})
+	if !errors.Is(err, warning.ErrClosed) {
+		t.Errorf("expected %v, got %v", warning.ErrClosed, err)
+	}
+}
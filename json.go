@@ -0,0 +1,438 @@
+package warning
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// JSONVersion is the version of the JSON Lines schema written by [NewJSONWriter].
+const JSONVersion = 1
+
+// jsonRecord is the JSON representation of a warning. Each line of a JSON Lines stream is one record:
+//
+//	{"version":1,"type":"example.com/custom","data":{...},"message":"unknown key","code":"W1042","severity":"warning","scope":"config",
+//	 "path":["servers",2,"tls"],"range":{"start":{"filename":"config.toml","line":12,"column":5}},
+//	 "tags":["deprecation"],"attrs":[{"key":"request_id","value":"req-1"}],"hint":"...",
+//	 "notes":[{"message":"...","range":{...}}],"fixes":[{"message":"...","edits":[{"range":{...},"newText":"..."}]}],
+//	 "seq":42,"time":"2024-01-01T00:00:00Z"}
+//
+// Only version and message are always present, other fields are omitted when not set.
+// Type and data are written for warnings of a type registered with [RegisterType].
+type jsonRecord struct {
+	Version  int             `json:"version"`
+	Type     string          `json:"type,omitempty"`
+	Data     json.RawMessage `json:"data,omitempty"`
+	Message  string          `json:"message"`
+	Code     string          `json:"code,omitempty"`
+	Severity Severity        `json:"severity"`
+	Scope    string          `json:"scope,omitempty"`
+	Path     []any           `json:"path,omitempty"`
+	Range    *jsonRange      `json:"range,omitempty"`
+	Tags     []Tag           `json:"tags,omitempty"`
+	Attrs    []jsonAttr      `json:"attrs,omitempty"`
+	Hint     string          `json:"hint,omitempty"`
+	Notes    []jsonNote      `json:"notes,omitempty"`
+	Fixes    []jsonFix       `json:"fixes,omitempty"`
+	Seq      uint64          `json:"seq,omitempty"`
+	Time     *time.Time      `json:"time,omitempty"`
+}
+
+type jsonPos struct {
+	Filename string `json:"filename,omitempty"`
+	Offset   int    `json:"offset,omitempty"`
+	Line     int    `json:"line,omitempty"`
+	Column   int    `json:"column,omitempty"`
+}
+
+type jsonRange struct {
+	Start jsonPos  `json:"start"`
+	End   *jsonPos `json:"end,omitempty"`
+}
+
+type jsonAttr struct {
+	Key   string `json:"key"`
+	Value any    `json:"value"`
+}
+
+type jsonNote struct {
+	Message string     `json:"message"`
+	Range   *jsonRange `json:"range,omitempty"`
+}
+
+type jsonFix struct {
+	Message string     `json:"message"`
+	Edits   []jsonEdit `json:"edits"`
+}
+
+type jsonEdit struct {
+	Range   *jsonRange `json:"range"`
+	NewText string     `json:"newText"`
+}
+
+func encodeRange(rng Range) *jsonRange {
+	if rng == (Range{}) {
+		return nil
+	}
+
+	encoded := &jsonRange{Start: jsonPos(rng.Start)}
+
+	if rng.End != (Position{}) {
+		end := jsonPos(rng.End)
+		encoded.End = &end
+	}
+
+	return encoded
+}
+
+func (rng *jsonRange) decode() Range {
+	if rng == nil {
+		return Range{}
+	}
+
+	decoded := Range{Start: Position(rng.Start)}
+
+	if rng.End != nil {
+		decoded.End = Position(*rng.End)
+	}
+
+	return decoded
+}
+
+// encodeRecord returns the JSON record of the warning.
+func encodeRecord(wrr Warning) (jsonRecord, error) {
+	rec := jsonRecord{
+		Version:  JSONVersion,
+		Message:  wrr.Warn(),
+		Code:     CodeOf(wrr),
+		Severity: SeverityOf(wrr),
+		Scope:    ScopeOf(wrr),
+		Tags:     TagsOf(wrr),
+		Hint:     HintOf(wrr),
+	}
+
+	if path, ok := PathOf(wrr); ok {
+		rec.Path = make([]any, 0, len(path))
+
+		for _, elem := range path {
+			if elem.IsIndex {
+				rec.Path = append(rec.Path, elem.Index)
+			} else {
+				rec.Path = append(rec.Path, elem.Field)
+			}
+		}
+	}
+
+	if rng, ok := RangeOf(wrr); ok {
+		rec.Range = encodeRange(rng)
+	}
+
+	for _, attr := range AttrsOf(wrr) {
+		rec.Attrs = append(rec.Attrs, jsonAttr(attr))
+	}
+
+	for _, note := range NotesOf(wrr) {
+		rec.Notes = append(rec.Notes, jsonNote{note.Message, encodeRange(note.Range)})
+	}
+
+	for _, fix := range FixesOf(wrr) {
+		encoded := jsonFix{fix.Message, make([]jsonEdit, 0, len(fix.Edits))}
+		for _, edit := range fix.Edits {
+			encoded.Edits = append(encoded.Edits, jsonEdit{encodeRange(edit.Range), edit.NewText})
+		}
+
+		rec.Fixes = append(rec.Fixes, encoded)
+	}
+
+	if seq, ok := SequenceOf(wrr); ok {
+		rec.Seq = seq
+	}
+
+	if t, ok := TimeOf(wrr); ok {
+		rec.Time = &t
+	}
+
+	var err error
+
+	rec.Type, rec.Data, err = encodeType(wrr)
+
+	return rec, err
+}
+
+// decode rebuilds a warning from the record using the same wrappers as the package functions,
+// so the accessor functions such as [CodeOf] or [RangeOf] return the encoded values.
+// Data already provided by a custom warning type is not wrapped again.
+func (rec *jsonRecord) decode() (Warning, error) {
+	wrr, err := decodeType(rec.Type, rec.Data, rec.Message)
+	if err != nil {
+		return nil, err
+	}
+
+	if rec.Code != "" && rec.Code != CodeOf(wrr) {
+		if def, ok := Lookup(rec.Code); ok {
+			wrr = def.Wrap(wrr)
+		} else {
+			wrr = WithCode(wrr, rec.Code)
+		}
+	}
+
+	if rec.Severity != SeverityOf(wrr) {
+		wrr = WithSeverity(wrr, rec.Severity)
+	}
+
+	if tags := slices.DeleteFunc(slices.Clone(rec.Tags), func(tag Tag) bool { return HasTag(wrr, tag) }); len(tags) > 0 {
+		wrr = WithTags(wrr, tags...)
+	}
+
+	if attrs := decodeAttrs(rec.Attrs); len(attrs) > 0 && !slices.EqualFunc(attrs, AttrsOf(wrr), equalAttr) {
+		wrr = WithAttrs(wrr, attrs...)
+	}
+
+	if rec.Path != nil {
+		path, err := decodePath(rec.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		if found, _ := PathOf(wrr); !slices.Equal(path, found) {
+			wrr = WithPath(wrr, path)
+		}
+	}
+
+	if rng := rec.Range.decode(); rec.Range != nil {
+		if found, _ := RangeOf(wrr); rng != found {
+			wrr = WithRange(wrr, rng)
+		}
+	}
+
+	if notes := decodeNotes(rec.Notes); len(notes) > 0 && !slices.Equal(notes, NotesOf(wrr)) {
+		wrr = WithNotes(wrr, notes...)
+	}
+
+	if rec.Hint != HintOf(wrr) {
+		wrr = WithHint(wrr, rec.Hint)
+	}
+
+	if fixes := decodeFixes(rec.Fixes); len(fixes) > 0 && !reflect.DeepEqual(fixes, FixesOf(wrr)) {
+		wrr = WithFixes(wrr, fixes...)
+	}
+
+	if rec.Scope != ScopeOf(wrr) {
+		wrr = &scopeWarning{wrr, rec.Scope}
+	}
+
+	if rec.Seq != 0 || rec.Time != nil {
+		stamped := &stampWarning{wrr: wrr, seq: rec.Seq}
+		if rec.Time != nil {
+			stamped.time = *rec.Time
+		}
+
+		wrr = stamped
+	}
+
+	return wrr, nil
+}
+
+func decodeAttrs(encoded []jsonAttr) []Attr {
+	var attrs []Attr
+	for _, attr := range encoded {
+		attrs = append(attrs, Attr(attr))
+	}
+
+	return attrs
+}
+
+// equalAttr reports whether the attributes are equal, attribute values are compared by their JSON encoding.
+func equalAttr(a, b Attr) bool {
+	if a.Key != b.Key {
+		return false
+	}
+
+	aData, aErr := json.Marshal(a.Value)
+	bData, bErr := json.Marshal(b.Value)
+
+	return aErr == nil && bErr == nil && bytes.Equal(aData, bData)
+}
+
+func decodeNotes(encoded []jsonNote) []Note {
+	var notes []Note
+	for _, note := range encoded {
+		notes = append(notes, Note{note.Message, note.Range.decode()})
+	}
+
+	return notes
+}
+
+func decodeFixes(encoded []jsonFix) []Fix {
+	var fixes []Fix
+
+	for _, fix := range encoded {
+		decoded := Fix{Message: fix.Message}
+		for _, edit := range fix.Edits {
+			decoded.Edits = append(decoded.Edits, Edit{edit.Range.decode(), edit.NewText})
+		}
+
+		fixes = append(fixes, decoded)
+	}
+
+	return fixes
+}
+
+func decodePath(elems []any) (Path, error) {
+	path := make(Path, 0, len(elems))
+
+	for _, elem := range elems {
+		switch elem := elem.(type) {
+		case string:
+			path = path.Field(elem)
+		case float64:
+			if elem != float64(int(elem)) {
+				return nil, fmt.Errorf("%w: path index %v is not an integer", ErrInvalidRecord, elem)
+			}
+
+			path = path.Index(int(elem))
+		default:
+			return nil, fmt.Errorf("%w: path element %v is neither a field nor an index", ErrInvalidRecord, elem)
+		}
+	}
+
+	return path, nil
+}
+
+type jsonWriter struct {
+	w   io.Writer
+	mtx sync.Mutex
+}
+
+// NewJSONWriter returns a Writer that writes each warning to w as a single line of JSON, following
+// version [JSONVersion] of the schema read by [NewJSONReader]. The message, code, severity, scope, path,
+// range, tags, attributes, hint, notes, fixes and stamp of the warning are written.
+// It is safe to write warnings concurrently, each one is written to w with a single Write call.
+func NewJSONWriter(w io.Writer) Writer {
+	return &jsonWriter{w: w}
+}
+
+func (writer *jsonWriter) WriteWarning(wrr Warning) error {
+	rec, err := encodeRecord(wrr)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+
+	if err := json.NewEncoder(&buf).Encode(rec); err != nil {
+		return err
+	}
+
+	writer.mtx.Lock()
+	defer writer.mtx.Unlock()
+
+	_, err = writer.w.Write(buf.Bytes())
+
+	return err
+}
+
+type jsonReader struct {
+	dec   *json.Decoder
+	lines *lineCounter
+	err   error
+	mtx   sync.Mutex
+}
+
+// NewJSONReader returns a Reader that decodes warnings written by [NewJSONWriter] from r.
+// Decoded warnings carry the encoded data and can be inspected with the accessor functions
+// such as [CodeOf], [RangeOf] or [AttrsOf]. Attribute values are decoded as by [encoding/json]
+// into an interface value. Codes registered in the default registry are bound to their [Definition].
+// Warnings of types registered with [RegisterType] are decoded into their original type, other typed
+// warnings are decoded as generic warnings that keep their type name, see [TypeOf].
+//
+// It returns [io.EOF] at the end of r, and [ErrUnsupportedVersion] for records written with a newer schema.
+// Errors report the line the failing record starts on. Once decoding fails, every following read returns
+// the same error.
+func NewJSONReader(r io.Reader) Reader {
+	lines := &lineCounter{r: r}
+
+	return &jsonReader{dec: json.NewDecoder(lines), lines: lines}
+}
+
+func (reader *jsonReader) ReadWarning() (Warning, error) {
+	reader.mtx.Lock()
+	defer reader.mtx.Unlock()
+
+	if reader.err != nil {
+		return nil, reader.err
+	}
+
+	wrr, err := reader.read()
+	if err != nil {
+		reader.err = err
+	}
+
+	return wrr, err
+}
+
+func (reader *jsonReader) read() (Warning, error) {
+	var rec jsonRecord
+
+	start := reader.dec.InputOffset()
+
+	err := reader.dec.Decode(&rec)
+	if errors.Is(err, io.EOF) {
+		return nil, io.EOF
+	}
+
+	line := reader.lines.lineAt(start)
+
+	if err != nil {
+		return nil, fmt.Errorf("line %d: %w", line, err)
+	}
+
+	if rec.Version > JSONVersion {
+		return nil, fmt.Errorf("line %d: %w %d", line, ErrUnsupportedVersion, rec.Version)
+	}
+
+	wrr, err := rec.decode()
+	if err != nil {
+		return nil, fmt.Errorf("line %d: %w", line, err)
+	}
+
+	return wrr, nil
+}
+
+// lineCounter counts the lines of the bytes read through it, so that records can be reported by line
+// even though the decoder reads ahead. Only the bytes read but not counted yet are kept.
+type lineCounter struct {
+	r       io.Reader
+	pending []byte
+	offset  int64 // offset of the first pending byte
+	lines   int   // number of line breaks before offset
+}
+
+func (c *lineCounter) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.pending = append(c.pending, p[:n]...)
+
+	return n, err
+}
+
+// lineAt returns the line of the first byte at or after offset that is not a space, which is where
+// the decoder starts a value after reading up to offset. Offsets must not decrease between calls.
+func (c *lineCounter) lineAt(offset int64) int {
+	i := int(offset - c.offset)
+	for i < len(c.pending) && strings.IndexByte(" \t\r\n", c.pending[i]) >= 0 {
+		i++
+	}
+
+	c.lines += bytes.Count(c.pending[:i], []byte("\n"))
+	c.pending = c.pending[i:]
+	c.offset += int64(i)
+
+	return c.lines + 1
+}
@@ -0,0 +1,186 @@
+package warning_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"go/token"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"go.wamod.dev/warning"
+)
+
+// ExampleNewJSONWriter demonstrates how to persist warnings as JSON Lines and read them back.
+func ExampleNewJSONWriter() {
+	var buf bytes.Buffer
+
+	ctx := warning.Attach(context.Background(), warning.NewJSONWriter(&buf))
+	warning.Warn(ctx, warning.WithCode(warning.New("unknown key"), "W1042"))
+
+	fmt.Print(buf.String())
+
+	wrrs, err := warning.ReadAll(warning.NewJSONReader(&buf))
+	if err != nil {
+		panic(err)
+	}
+
+	for _, wrr := range wrrs {
+		fmt.Printf("%s: %s\n", warning.CodeOf(wrr), wrr.Warn())
+	}
+
+	// Output:
+	// {"version":1,"message":"unknown key","code":"W1042","severity":"warning"}
+	// W1042: unknown key
+}
+
+var jsonDefinition = warning.MustRegister(warning.Definition{ //nolint:gochecknoglobals
+	Code:     "JSON1",
+	Severity: warning.SeverityError,
+	Tags:     []warning.Tag{warning.TagUnnecessary},
+	URL:      "https://example.com/JSON1",
+})
+
+func TestJSONRoundTrip(t *testing.T) {
+	rng := warning.Range{
+		Start: token.Position{Filename: "main.go", Offset: 10, Line: 2, Column: 3},
+		End:   token.Position{Filename: "main.go", Offset: 14, Line: 2, Column: 7},
+	}
+
+	collector := warning.NewCollector()
+	ctx := warning.Attach(context.Background(), collector)
+	warning.Warnf(warning.WithClock(ctx, func() time.Time { return time.Unix(1700000000, 5).UTC() }), "formatted %d", 1)
+	warning.Warnf(warning.WithScope(ctx, "db.migrations"), "scoped")
+	warning.Warnf(warning.Enrich(ctx, func(context.Context) []warning.Attr {
+		return []warning.Attr{{Key: "request_id", Value: "req-1"}}
+	}), "enriched")
+
+	collected, err := warning.ReadAll(collector)
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	tests := []struct {
+		name string
+		wrr  warning.Warning
+	}{
+		{"string", warning.New("test")},
+		{"format", collected[0]},
+		{"scope", collected[1]},
+		{"attrs", collected[2]},
+		{"definition", jsonDefinition.New("test")},
+		{"definition override", warning.WithSeverity(jsonDefinition.New("test"), warning.SeverityInfo)},
+		{"code", warning.WithCode(warning.New("test"), "W1")},
+		{"severity", warning.WithSeverity(warning.New("test"), warning.SeverityHint)},
+		{"tags", warning.WithTags(warning.New("test"), warning.TagDeprecation, warning.TagLate)},
+		{"path", warning.WithPath(warning.New("test"), warning.Path{}.Field("servers").Index(2).Field("a.b").Field(""))},
+		{"position", warning.WithPosition(warning.New("test"), rng.Start)},
+		{"range", warning.WithRange(warning.New("test"), rng)},
+		{"notes", warning.WithNotes(warning.New("test"), warning.Note{Message: "here", Range: rng}, warning.Note{Message: "no range"})},
+		{"hint", warning.WithHint(warning.New("test"), "try again")},
+		{"fixes", warning.WithFixes(warning.New("test"), warning.Fix{
+			Message: "rename",
+			Edits:   []warning.Edit{{Range: rng, NewText: "name"}, {Range: warning.Range{Start: rng.Start}, NewText: "x"}},
+		})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			if err := warning.NewJSONWriter(&buf).WriteWarning(tt.wrr); err != nil {
+				t.Fatalf("expected nil error, got %v", err)
+			}
+
+			if strings.Count(buf.String(), "\n") != 1 {
+				t.Errorf("expected a single line, got %q", buf.String())
+			}
+
+			wrrs, err := warning.ReadAll(warning.NewJSONReader(&buf))
+			if err != nil {
+				t.Fatalf("expected nil error, got %v", err)
+			}
+
+			if len(wrrs) != 1 {
+				t.Fatalf("expected 1 warning, got %v", wrrs)
+			}
+
+			assertSameWarning(t, tt.wrr, wrrs[0])
+		})
+	}
+}
+
+func assertSameWarning(t *testing.T, want, got warning.Warning) {
+	t.Helper()
+
+	verbose := warning.FormatOptions{Verbose: true}
+	if w, g := warning.Format(want, verbose), warning.Format(got, verbose); w != g {
+		t.Errorf("expected:\n%s\ngot:\n%s", w, g)
+	}
+
+	if w, g := warning.NotesOf(want), warning.NotesOf(got); !reflect.DeepEqual(w, g) {
+		t.Errorf("expected notes %v, got %v", w, g)
+	}
+
+	if w, g := warning.FixesOf(want), warning.FixesOf(got); !reflect.DeepEqual(w, g) {
+		t.Errorf("expected fixes %v, got %v", w, g)
+	}
+
+	wantDef, _ := warning.DefinitionOf(want)
+	if gotDef, _ := warning.DefinitionOf(got); wantDef != gotDef {
+		t.Errorf("expected definition %v, got %v", wantDef, gotDef)
+	}
+
+	wantSeq, wantOK := warning.SequenceOf(want)
+	if gotSeq, gotOK := warning.SequenceOf(got); wantSeq != gotSeq || wantOK != gotOK {
+		t.Errorf("expected sequence %v, got %v", wantSeq, gotSeq)
+	}
+
+	wantTime, _ := warning.TimeOf(want)
+	if gotTime, _ := warning.TimeOf(got); !wantTime.Equal(gotTime) {
+		t.Errorf("expected time %v, got %v", wantTime, gotTime)
+	}
+}
+
+func TestJSONReader_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		err  error
+		text string
+	}{
+		{"syntax", "{\"version\":1,\"message\":\"a\"}\n{\"version\":1,", nil, "line 2: unexpected EOF"},
+		{"blank lines", "\n{\"version\":1,\n\"message\":\"a\"}\n\n{\"version\":2}", warning.ErrUnsupportedVersion, "line 5: unsupported schema version 2"},
+		{"version", `{"version":2,"message":"a"}`, warning.ErrUnsupportedVersion, "line 1: unsupported schema version 2"},
+		{"severity", `{"message":"a","severity":"fatal"}`, warning.ErrUnknownSeverity, "line 1"},
+		{"path index", `{"message":"a","path":[1.5]}`, warning.ErrInvalidRecord, "line 1: invalid warning record: path index 1.5 is not an integer"},
+		{"path element", `{"message":"a","path":[true]}`, warning.ErrInvalidRecord, "line 1: invalid warning record: path element true"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader := warning.NewJSONReader(strings.NewReader(tt.data))
+
+			_, err := warning.ReadAll(reader)
+			if err == nil || !strings.Contains(err.Error(), tt.text) {
+				t.Fatalf("expected error containing %q, got %v", tt.text, err)
+			}
+
+			if tt.err != nil && !errors.Is(err, tt.err) {
+				t.Errorf("expected %v, got %v", tt.err, err)
+			}
+
+			if _, again := reader.ReadWarning(); !errors.Is(again, err) {
+				t.Errorf("expected the same error, got %v", again)
+			}
+		})
+	}
+
+	if _, err := warning.NewJSONReader(strings.NewReader("")).ReadWarning(); !errors.Is(err, io.EOF) {
+		t.Errorf("expected %v, got %v", io.EOF, err)
+	}
+}
@@ -0,0 +1,122 @@
+package warning
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strconv"
+)
+
+// JUnitOptions controls how [EncodeJUnit] renders warnings.
+type JUnitOptions struct {
+	// SuiteName is the name of the test suite. It defaults to "warnings".
+	SuiteName string
+	// GroupBy selects how warnings are grouped into test cases. With [GroupByNone],
+	// each warning is a test case of its own, named after its message.
+	GroupBy GroupBy
+	// Strict reports each test case as a failure. Otherwise warnings are written to the system-out
+	// of passing test cases.
+	Strict bool
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Errors   int             `xml:"errors,attr"`
+	Skipped  int             `xml:"skipped,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut *junitOutput  `xml:"system-out,omitempty"`
+}
+
+type junitOutput struct {
+	Text string `xml:",cdata"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",cdata"`
+}
+
+// EncodeJUnit writes the warnings read from r to w as a JUnit XML report with a single test suite,
+// so that warnings show up in CI dashboards that read test reports. Warnings are grouped into test cases
+// as selected by opts.GroupBy, each warning is rendered like [RenderSnippet] without the source line.
+func EncodeJUnit(w io.Writer, r Reader, opts JUnitOptions) error {
+	wrrs, err := ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	suite := junitTestSuite{Name: opts.SuiteName}
+	if suite.Name == "" {
+		suite.Name = "warnings"
+	}
+
+	for _, group := range groupWarnings(wrrs, opts.GroupBy) {
+		var text bytes.Buffer
+
+		severity := SeverityHint
+
+		for _, wrr := range group.wrrs {
+			if err := RenderSnippet(&text, wrr, nil); err != nil {
+				return err
+			}
+
+			severity = max(severity, SeverityOf(wrr))
+		}
+
+		testCase := junitTestCase{Name: groupName(group.key, opts.GroupBy), ClassName: suite.Name}
+
+		if opts.Strict {
+			message := "1 warning"
+			if len(group.wrrs) > 1 {
+				message = strconv.Itoa(len(group.wrrs)) + " warnings"
+			}
+
+			testCase.Failure = &junitFailure{message, severity.String(), text.String()}
+			suite.Failures++
+		} else {
+			testCase.SystemOut = &junitOutput{text.String()}
+		}
+
+		suite.Cases = append(suite.Cases, testCase)
+	}
+
+	suite.Tests = len(suite.Cases)
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+
+	err = enc.Encode(junitTestSuites{
+		Name:     suite.Name,
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Suites:   []junitTestSuite{suite},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, "\n")
+
+	return err
+}
@@ -0,0 +1,121 @@
+package warning_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"go.wamod.dev/warning"
+)
+
+type junitReport struct {
+	Tests    int `xml:"tests,attr"`
+	Failures int `xml:"failures,attr"`
+	Suites   []struct {
+		Name  string `xml:"name,attr"`
+		Tests int    `xml:"tests,attr"`
+		Cases []struct {
+			Name    string `xml:"name,attr"`
+			Failure *struct {
+				Message string `xml:"message,attr"`
+				Type    string `xml:"type,attr"`
+				Text    string `xml:",chardata"`
+			} `xml:"failure"`
+			SystemOut string `xml:"system-out"`
+		} `xml:"testcase"`
+	} `xml:"testsuite"`
+}
+
+func encodeJUnit(t *testing.T, wrrs []warning.Warning, opts warning.JUnitOptions) junitReport {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	if err := warning.EncodeJUnit(&buf, warning.NewSliceReader(wrrs), opts); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	if !strings.HasPrefix(buf.String(), xml.Header) {
+		t.Errorf("expected XML header, got %q", buf.String())
+	}
+
+	var report junitReport
+	if err := xml.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("expected valid XML, got %v", err)
+	}
+
+	if len(report.Suites) != 1 {
+		t.Fatalf("expected 1 test suite, got %d", len(report.Suites))
+	}
+
+	return report
+}
+
+func TestEncodeJUnit_Strict(t *testing.T) {
+	report := encodeJUnit(t, reportWarnings(), warning.JUnitOptions{GroupBy: warning.GroupByCode, Strict: true})
+
+	if report.Tests != 3 || report.Failures != 3 {
+		t.Errorf("expected 3 tests and 3 failures, got %d and %d", report.Tests, report.Failures)
+	}
+
+	suite := report.Suites[0]
+	if suite.Name != "warnings" {
+		t.Errorf("expected suite name warnings, got %v", suite.Name)
+	}
+
+	names := []string{"SARIF1", "W2", "(no code)"}
+	for i, testCase := range suite.Cases {
+		if testCase.Name != names[i] {
+			t.Errorf("expected test case %v, got %v", names[i], testCase.Name)
+		}
+
+		if testCase.Failure == nil {
+			t.Fatalf("expected failure for %v", testCase.Name)
+		}
+	}
+
+	failure := suite.Cases[0].Failure
+	if failure.Message != "3 warnings" || failure.Type != "error" {
+		t.Errorf("expected 3 errors, got %v %v", failure.Message, failure.Type)
+	}
+
+	if !strings.Contains(failure.Text, "config/app.toml:3:1-3:8: error[SARIF1]: unknown key \"tiemout\"\n") {
+		t.Errorf("expected rendered warning, got %q", failure.Text)
+	}
+}
+
+func TestEncodeJUnit_Lenient(t *testing.T) {
+	writer := &mockWriter{}
+	ctx := warning.Attach(context.Background(), writer)
+	warning.Warn(warning.WithScope(ctx, "db"), warning.New("slow query"), warning.New("missing index"))
+	warning.Warn(ctx, warning.New("unscoped"))
+
+	report := encodeJUnit(t, writer.buf, warning.JUnitOptions{SuiteName: "lint", GroupBy: warning.GroupByScope})
+
+	if report.Tests != 2 || report.Failures != 0 {
+		t.Errorf("expected 2 tests and no failures, got %d and %d", report.Tests, report.Failures)
+	}
+
+	suite := report.Suites[0]
+	if suite.Name != "lint" || suite.Cases[0].Name != "db" || suite.Cases[1].Name != "(no scope)" {
+		t.Errorf("unexpected test cases %+v", suite)
+	}
+
+	if got := suite.Cases[0].SystemOut; got != "warning: slow query\nwarning: missing index\n" {
+		t.Errorf("expected system-out with both warnings, got %q", got)
+	}
+
+	if suite.Cases[0].Failure != nil {
+		t.Errorf("expected no failure in lenient mode")
+	}
+}
+
+func TestEncodeJUnit_NoGrouping(t *testing.T) {
+	report := encodeJUnit(t, []warning.Warning{warning.New("first"), warning.New("first")}, warning.JUnitOptions{})
+
+	if suite := report.Suites[0]; len(suite.Cases) != 2 || suite.Cases[0].Name != "first" {
+		t.Errorf("expected a test case per warning, got %+v", suite)
+	}
+}
@@ -0,0 +1,247 @@
+package warning
+
+import (
+	"path/filepath"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// LSPPosition is a zero-based position in a text document, as defined by the Language Server Protocol.
+// Character is counted in UTF-16 code units.
+type LSPPosition struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+// LSPRange is a range in a text document, End is exclusive.
+type LSPRange struct {
+	Start LSPPosition `json:"start"`
+	End   LSPPosition `json:"end"`
+}
+
+// LSPLocation is a range inside the document identified by URI.
+type LSPLocation struct {
+	URI   string   `json:"uri"`
+	Range LSPRange `json:"range"`
+}
+
+// LSPRelatedInformation points to a location related to a diagnostic, such as a previous definition.
+type LSPRelatedInformation struct {
+	Location LSPLocation `json:"location"`
+	Message  string      `json:"message"`
+}
+
+// LSPCodeDescription points to the documentation of a diagnostic code.
+type LSPCodeDescription struct {
+	Href string `json:"href"`
+}
+
+// LSPDiagnosticSeverity is the severity of a diagnostic.
+type LSPDiagnosticSeverity int
+
+// Diagnostic severities defined by the Language Server Protocol.
+const (
+	LSPSeverityError       LSPDiagnosticSeverity = 1
+	LSPSeverityWarning     LSPDiagnosticSeverity = 2
+	LSPSeverityInformation LSPDiagnosticSeverity = 3
+	LSPSeverityHint        LSPDiagnosticSeverity = 4
+)
+
+// LSPDiagnosticTag is additional metadata about a diagnostic, used by editors to render it.
+type LSPDiagnosticTag int
+
+// Diagnostic tags defined by the Language Server Protocol.
+const (
+	LSPTagUnnecessary LSPDiagnosticTag = 1
+	LSPTagDeprecated  LSPDiagnosticTag = 2
+)
+
+// LSPDiagnostic is a diagnostic as defined by the Language Server Protocol.
+type LSPDiagnostic struct {
+	Range              LSPRange                `json:"range"`
+	Severity           LSPDiagnosticSeverity   `json:"severity"`
+	Code               string                  `json:"code,omitempty"`
+	CodeDescription    *LSPCodeDescription     `json:"codeDescription,omitempty"`
+	Source             string                  `json:"source,omitempty"`
+	Message            string                  `json:"message"`
+	Tags               []LSPDiagnosticTag      `json:"tags,omitempty"`
+	RelatedInformation []LSPRelatedInformation `json:"relatedInformation,omitempty"`
+}
+
+// LSPPublishDiagnosticsParams are the parameters of a textDocument/publishDiagnostics notification.
+type LSPPublishDiagnosticsParams struct {
+	URI         string          `json:"uri"`
+	Diagnostics []LSPDiagnostic `json:"diagnostics"`
+}
+
+// LSPOptions controls how [LSPDiagnostics] converts warnings.
+type LSPOptions struct {
+	// Source is the name written as the source of every diagnostic, e.g. the name of the tool.
+	Source string
+	// Files loads the content of source files, which is needed to count characters in UTF-16 code units
+	// and to resolve positions that only have an offset. If nil, columns are assumed to count characters.
+	Files SourceFunc
+}
+
+// LSPDiagnostics converts the warnings read from r to Language Server Protocol diagnostics, grouped
+// by document in the order the documents first appear. Each group can be sent as the parameters of
+// a textDocument/publishDiagnostics notification.
+//
+// Codes are written along with the documentation URL of their [Definition], notes with a source file
+// become related information, other notes and the hint are added to the message on separate lines,
+// and the [TagDeprecation] and [TagUnnecessary] tags become the matching diagnostic tags.
+// File names are converted to file URIs, relative names are made absolute first.
+// Warnings without a file cannot be published to a document and are left out.
+func LSPDiagnostics(r Reader, opts LSPOptions) ([]LSPPublishDiagnosticsParams, error) {
+	wrrs, err := ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	conv := lspConverter{files: opts.Files, cache: make(map[string][]byte)}
+
+	var result []LSPPublishDiagnosticsParams
+
+	index := make(map[string]int)
+
+	for _, wrr := range wrrs {
+		rng, ok := RangeOf(wrr)
+		if !ok || rng.Start.Filename == "" {
+			continue
+		}
+
+		diag := LSPDiagnostic{
+			Range:    conv.lspRange(rng),
+			Severity: lspSeverity(SeverityOf(wrr)),
+			Code:     CodeOf(wrr),
+			Source:   opts.Source,
+			Message:  wrr.Warn(),
+		}
+
+		if def, ok := DefinitionOf(wrr); ok && def.URL != "" {
+			diag.CodeDescription = &LSPCodeDescription{def.URL}
+		}
+
+		for _, tag := range TagsOf(wrr) {
+			switch tag {
+			case TagUnnecessary:
+				diag.Tags = append(diag.Tags, LSPTagUnnecessary)
+			case TagDeprecation:
+				diag.Tags = append(diag.Tags, LSPTagDeprecated)
+			}
+		}
+
+		for _, note := range NotesOf(wrr) {
+			if note.Range.Start.Filename == "" {
+				diag.Message += "\nnote: " + noteText(note)
+
+				continue
+			}
+
+			diag.RelatedInformation = append(diag.RelatedInformation, LSPRelatedInformation{
+				Location: LSPLocation{lspURI(note.Range.Start.Filename), conv.lspRange(note.Range)},
+				Message:  note.Message,
+			})
+		}
+
+		if hint := HintOf(wrr); hint != "" {
+			diag.Message += "\nhint: " + hint
+		}
+
+		uri := lspURI(rng.Start.Filename)
+
+		i, ok := index[uri]
+		if !ok {
+			i = len(result)
+			index[uri] = i
+			result = append(result, LSPPublishDiagnosticsParams{URI: uri})
+		}
+
+		result[i].Diagnostics = append(result[i].Diagnostics, diag)
+	}
+
+	return result, nil
+}
+
+func lspSeverity(severity Severity) LSPDiagnosticSeverity {
+	switch {
+	case severity >= SeverityError:
+		return LSPSeverityError
+	case severity == SeverityWarning:
+		return LSPSeverityWarning
+	case severity == SeverityInfo:
+		return LSPSeverityInformation
+	default:
+		return LSPSeverityHint
+	}
+}
+
+func lspURI(filename string) string {
+	if abs, err := filepath.Abs(filename); err == nil {
+		filename = abs
+	}
+
+	return fileURL(filepath.ToSlash(filename))
+}
+
+type lspConverter struct {
+	files SourceFunc
+	cache map[string][]byte
+}
+
+// content returns the content of the file, or nil if it cannot be loaded.
+func (conv *lspConverter) content(filename string) []byte {
+	if conv.files == nil {
+		return nil
+	}
+
+	content, ok := conv.cache[filename]
+	if !ok {
+		content, _ = conv.files(filename)
+		conv.cache[filename] = content
+	}
+
+	return content
+}
+
+func (conv *lspConverter) lspRange(rng Range) LSPRange {
+	start := conv.lspPosition(rng.Start)
+
+	end := start
+	if rng.End.IsValid() || rng.End.Offset > 0 {
+		if rng.End.Filename == "" {
+			rng.End.Filename = rng.Start.Filename
+		}
+
+		end = conv.lspPosition(rng.End)
+	}
+
+	return LSPRange{start, end}
+}
+
+func (conv *lspConverter) lspPosition(pos Position) LSPPosition {
+	content := conv.content(pos.Filename)
+	if content == nil {
+		return LSPPosition{Line: max(pos.Line-1, 0), Character: max(pos.Column-1, 0)}
+	}
+
+	pos, _ = resolve(content, pos)
+	result := LSPPosition{Line: max(pos.Line-1, 0), Character: max(pos.Column-1, 0)}
+
+	start, end, ok := lineBounds(content, pos.Line)
+	if !ok {
+		return result
+	}
+
+	// count the UTF-16 code units before the column
+	line := content[start:min(start+result.Character, end)]
+	result.Character = 0
+
+	for len(line) > 0 {
+		r, size := utf8.DecodeRune(line)
+		result.Character += utf16.RuneLen(r)
+		line = line[size:]
+	}
+
+	return result
+}
@@ -0,0 +1,108 @@
+package warning_test
+
+import (
+	"encoding/json"
+	"go/token"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"go.wamod.dev/warning"
+)
+
+func TestLSPDiagnostics(t *testing.T) {
+	dir := t.TempDir()
+	name := filepath.Join(dir, "app.toml")
+	other := filepath.Join(dir, "base.toml")
+
+	files := mockSource(map[string]string{
+		name: "[server]\nnamé = \"😀\" # 5s\n",
+	})
+
+	def := warning.NewRegistry().MustRegister(warning.Definition{
+		Code: "L1",
+		URL:  "https://example.com/L1",
+		Tags: []warning.Tag{warning.TagDeprecation},
+	})
+
+	wrrs := []warning.Warning{
+		warning.WithHint(warning.WithNotes(
+			// the range covers the emoji, columns are counted in bytes
+			warning.WithRange(def.New("deprecated value"), warning.Range{
+				Start: token.Position{Filename: name, Line: 2, Column: 10},
+				End:   token.Position{Line: 2, Column: 14},
+			}),
+			warning.Note{Message: "defined here", Range: warning.Range{Start: token.Position{Filename: other, Line: 1, Column: 1}}},
+			warning.Note{Message: "no location"},
+		), "use the new value"),
+		warning.WithPosition(
+			warning.WithTags(warning.WithSeverity(warning.New("unused"), warning.SeverityHint), warning.TagUnnecessary),
+			token.Position{Filename: other, Line: 3, Column: 4},
+		),
+		warning.WithPosition(warning.WithSeverity(warning.New("by offset"), warning.SeverityError), token.Position{Filename: name, Offset: 9}),
+		warning.New("no file"),
+	}
+
+	params, err := warning.LSPDiagnostics(warning.NewSliceReader(wrrs), warning.LSPOptions{Source: "confcheck", Files: files})
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	if len(params) != 2 {
+		t.Fatalf("expected 2 documents, got %+v", params)
+	}
+
+	uri := "file://" + filepath.ToSlash(name)
+	if params[0].URI != uri {
+		t.Errorf("expected URI %v, got %v", uri, params[0].URI)
+	}
+
+	// only notes with a file become related information, the others are added to the message
+	first := params[0].Diagnostics[0]
+	want := warning.LSPDiagnostic{
+		Range: warning.LSPRange{
+			Start: warning.LSPPosition{Line: 1, Character: 8},
+			End:   warning.LSPPosition{Line: 1, Character: 10},
+		},
+		Severity:        warning.LSPSeverityWarning,
+		Code:            "L1",
+		CodeDescription: &warning.LSPCodeDescription{Href: "https://example.com/L1"},
+		Source:          "confcheck",
+		Message:         "deprecated value\nnote: no location\nhint: use the new value",
+		Tags:            []warning.LSPDiagnosticTag{warning.LSPTagDeprecated},
+		RelatedInformation: []warning.LSPRelatedInformation{{
+			Location: warning.LSPLocation{URI: params[1].URI, Range: warning.LSPRange{}},
+			Message:  "defined here",
+		}},
+	}
+
+	if !reflect.DeepEqual(first, want) {
+		t.Errorf("expected:\n%+v\ngot:\n%+v", want, first)
+	}
+
+	byOffset := params[0].Diagnostics[1]
+	if byOffset.Severity != warning.LSPSeverityError || byOffset.Range.Start != (warning.LSPPosition{Line: 1, Character: 0}) {
+		t.Errorf("unexpected diagnostic %+v", byOffset)
+	}
+
+	unused := params[1].Diagnostics[0]
+	if unused.Severity != warning.LSPSeverityHint || !reflect.DeepEqual(unused.Tags, []warning.LSPDiagnosticTag{warning.LSPTagUnnecessary}) {
+		t.Errorf("unexpected diagnostic %+v", unused)
+	}
+
+	// without source files, columns are used as is
+	if unused.Range.Start != (warning.LSPPosition{Line: 2, Character: 3}) || unused.Range.End != unused.Range.Start {
+		t.Errorf("unexpected range %+v", unused.Range)
+	}
+
+	data, err := json.Marshal(params[1])
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	wantJSON := `{"uri":"` + params[1].URI + `","diagnostics":[{"range":{"start":{"line":2,"character":3},` +
+		`"end":{"line":2,"character":3}},"severity":4,"source":"confcheck","message":"unused","tags":[1]}]}`
+	if string(data) != wantJSON {
+		t.Errorf("expected %s, got %s", wantJSON, data)
+	}
+}
@@ -0,0 +1,142 @@
+package warning
+
+import (
+	"bytes"
+	"html"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// MarkdownOptions controls how [EncodeMarkdown] renders warnings.
+type MarkdownOptions struct {
+	// Title is the heading of the report. It defaults to "Warnings".
+	Title string
+	// Limit is the largest number of warnings listed for each code, the others are only counted.
+	// Zero lists all warnings, which can exceed the size limit of pull request comments.
+	Limit int
+}
+
+// EncodeMarkdown writes the warnings read from r to w as a GitHub-flavoured Markdown report, meant to be
+// posted as a pull request comment. The report starts with the summary written by [RenderText] and a table
+// counting the warnings of each code, followed by a collapsible section listing the warnings of each code
+// with their notes and hints.
+func EncodeMarkdown(w io.Writer, r Reader, opts MarkdownOptions) error {
+	wrrs, err := ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	title := opts.Title
+	if title == "" {
+		title = "Warnings"
+	}
+
+	var buf bytes.Buffer
+
+	buf.WriteString("## " + markdownEscape(title) + "\n\n")
+	buf.WriteString(textSummary(wrrs) + ".\n")
+
+	groups := groupWarnings(wrrs, GroupByCode)
+
+	if len(groups) > 0 {
+		buf.WriteString("\n| Code | Severity | Count | Title |\n| --- | --- | ---: | --- |\n")
+	}
+
+	for _, group := range groups {
+		code := markdownEscape(groupName(group.key, GroupByCode))
+
+		var title string
+		if def, ok := DefinitionOf(group.wrrs[0]); ok {
+			title = def.Title
+
+			if def.URL != "" {
+				code = "[" + code + "](" + markdownURLEscaper.Replace(def.URL) + ")"
+			}
+		}
+
+		buf.WriteString("| " + code +
+			" | " + maxSeverity(group.wrrs).String() +
+			" | " + strconv.Itoa(len(group.wrrs)) +
+			" | " + markdownEscape(title) + " |\n")
+	}
+
+	for _, group := range groups {
+		buf.WriteString("\n<details>\n<summary>")
+		buf.WriteString(html.EscapeString(groupName(group.key, GroupByCode) + ": " + plural(len(group.wrrs), "warning")))
+		buf.WriteString("</summary>\n\n")
+
+		listed := group.wrrs
+		if opts.Limit > 0 && len(listed) > opts.Limit {
+			listed = listed[:opts.Limit]
+		}
+
+		for _, wrr := range listed {
+			buf.WriteString("- **" + SeverityOf(wrr).String() + "**")
+
+			if location := locationOf(wrr); location != "" {
+				buf.WriteString(" `" + location + "`")
+			}
+
+			buf.WriteString(" " + markdownEscape(wrr.Warn()) + "\n")
+
+			for _, note := range NotesOf(wrr) {
+				buf.WriteString("  - note: " + markdownEscape(noteText(note)) + "\n")
+			}
+
+			if hint := HintOf(wrr); hint != "" {
+				buf.WriteString("  - hint: " + markdownEscape(hint) + "\n")
+			}
+		}
+
+		if n := len(group.wrrs) - len(listed); n > 0 {
+			buf.WriteString("- … and " + strconv.Itoa(n) + " more\n")
+		}
+
+		buf.WriteString("\n</details>\n")
+	}
+
+	_, err = w.Write(buf.Bytes())
+
+	return err
+}
+
+// locationOf returns the source range of the warning, or its path if it has no range.
+func locationOf(wrr Warning) string {
+	if rng, ok := RangeOf(wrr); ok {
+		return rng.String()
+	}
+
+	if path, ok := PathOf(wrr); ok && len(path) > 0 {
+		return path.String()
+	}
+
+	return ""
+}
+
+func maxSeverity(wrrs []Warning) Severity {
+	severity := SeverityHint
+	for _, wrr := range wrrs {
+		severity = max(severity, SeverityOf(wrr))
+	}
+
+	return severity
+}
+
+//nolint:gochecknoglobals
+var markdownReplacer = strings.NewReplacer(
+	`\`, `\\`, "&", "&amp;", "`", "\\`", "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`,
+	"<", "&lt;", ">", "&gt;", "|", `\|`, "\r\n", "<br>", "\n", "<br>",
+)
+
+// markdownURLEscaper percent-encodes the characters that end a link destination or a table cell.
+//
+//nolint:gochecknoglobals
+var markdownURLEscaper = strings.NewReplacer(
+	" ", "%20", "(", "%28", ")", "%29", "<", "%3C", ">", "%3E", "|", "%7C", "\\", "%5C", "\n", "%0A", "\r", "%0D",
+)
+
+// markdownEscape escapes text so that it is rendered literally, on a single line, in Markdown tables and lists.
+func markdownEscape(text string) string {
+	return markdownReplacer.Replace(text)
+}
@@ -0,0 +1,117 @@
+package warning_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"go.wamod.dev/warning"
+)
+
+func TestEncodeMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := warning.EncodeMarkdown(&buf, warning.NewSliceReader(reportWarnings()), warning.MarkdownOptions{Title: "Lint", Limit: 2})
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	want := "## Lint\n" +
+		"\n" +
+		"5 warnings, 3 errors in 2 files.\n" +
+		"\n" +
+		"| Code | Severity | Count | Title |\n" +
+		"| --- | --- | ---: | --- |\n" +
+		"| [SARIF1](https://example.com/SARIF1) | error | 3 | Unknown key |\n" +
+		"| W2 | warning | 1 |  |\n" +
+		"| (no code) | info | 1 |  |\n" +
+		"\n" +
+		"<details>\n" +
+		"<summary>SARIF1: 3 warnings</summary>\n" +
+		"\n" +
+		"- **error** `config/app.toml:3:1-3:8` unknown key \"tiemout\"\n" +
+		"  - note: config/app.toml:3:1: defined here\n" +
+		"- **error** unknown key \"tiemout\"\n" +
+		"- … and 1 more\n" +
+		"\n" +
+		"</details>\n" +
+		"\n" +
+		"<details>\n" +
+		"<summary>W2: 1 warning</summary>\n" +
+		"\n" +
+		"- **warning** `/src/main.go:10:2` deprecated option\n" +
+		"\n" +
+		"</details>\n" +
+		"\n" +
+		"<details>\n" +
+		"<summary>(no code): 1 warning</summary>\n" +
+		"\n" +
+		"- **info** `servers[0]` value out of range\n" +
+		"\n" +
+		"</details>\n"
+
+	if got := buf.String(); got != want {
+		t.Errorf("expected\n%s\ngot\n%s", want, got)
+	}
+}
+
+func TestEncodeMarkdown_Escaping(t *testing.T) {
+	var buf bytes.Buffer
+
+	wrrs := []warning.Warning{warning.WithHint(warning.New("a | b <br> *c*\nd"), "use `e`")}
+
+	if err := warning.EncodeMarkdown(&buf, warning.NewSliceReader(wrrs), warning.MarkdownOptions{}); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	want := "## Warnings\n" +
+		"\n" +
+		"1 warning.\n" +
+		"\n" +
+		"| Code | Severity | Count | Title |\n" +
+		"| --- | --- | ---: | --- |\n" +
+		"| (no code) | warning | 1 |  |\n" +
+		"\n" +
+		"<details>\n" +
+		"<summary>(no code): 1 warning</summary>\n" +
+		"\n" +
+		"- **warning** a \\| b &lt;br&gt; \\*c\\*<br>d\n" +
+		"  - hint: use \\`e\\`\n" +
+		"\n" +
+		"</details>\n"
+
+	if got := buf.String(); got != want {
+		t.Errorf("expected\n%s\ngot\n%s", want, got)
+	}
+}
+
+var markdownDefinition = warning.MustRegister(warning.Definition{ //nolint:gochecknoglobals
+	Code: "MD1",
+	URL:  "https://example.com/docs (v2)/MD1|a",
+})
+
+func TestEncodeMarkdown_URL(t *testing.T) {
+	var buf bytes.Buffer
+
+	wrrs := []warning.Warning{markdownDefinition.New("test")}
+
+	if err := warning.EncodeMarkdown(&buf, warning.NewSliceReader(wrrs), warning.MarkdownOptions{}); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	if want := "| [MD1](https://example.com/docs%20%28v2%29/MD1%7Ca) | warning | 1 |  |\n"; !strings.Contains(buf.String(), want) {
+		t.Errorf("expected output to contain %q, got %s", want, buf.String())
+	}
+}
+
+func TestEncodeMarkdown_Empty(t *testing.T) {
+	var buf bytes.Buffer
+
+	if err := warning.EncodeMarkdown(&buf, warning.NewSliceReader(nil), warning.MarkdownOptions{}); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	if got, want := buf.String(), "## Warnings\n\nno warnings.\n"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
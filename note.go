@@ -0,0 +1,103 @@
+package warning
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Note is secondary information attached to a warning, such as "previous definition here".
+type Note struct {
+	// Message describes the note.
+	Message string
+	// Range optionally points to the source the note refers to.
+	Range Range
+}
+
+type noteWarning struct {
+	wrr   Warning
+	notes []Note
+}
+
+// WithNotes returns a warning that carries the given related notes.
+func WithNotes(wrr Warning, notes ...Note) Warning {
+	return &noteWarning{wrr, notes}
+}
+
+func (wrr *noteWarning) Warn() string {
+	return wrr.wrr.Warn()
+}
+
+func (wrr *noteWarning) Format(state fmt.State, verb rune) {
+	formatState(state, verb, wrr)
+}
+
+func (wrr *noteWarning) String() string {
+	return stringOf(wrr.wrr)
+}
+
+func (wrr *noteWarning) MarshalJSON() ([]byte, error) {
+	return json.Marshal(wrr.wrr)
+}
+
+func (wrr *noteWarning) Notes() []Note {
+	return wrr.notes
+}
+
+func (wrr *noteWarning) Unwrap() Warning {
+	return wrr.wrr
+}
+
+// NotesOf returns the related notes of the warning or any warning it wraps.
+func NotesOf(wrr Warning) []Note {
+	found, ok := find[interface{ Notes() []Note }](wrr)
+	if !ok {
+		return nil
+	}
+
+	return found.Notes()
+}
+
+type hintWarning struct {
+	wrr  Warning
+	hint string
+}
+
+// WithHint returns a warning that carries a hint, such as "did you mean timeout?".
+func WithHint(wrr Warning, hint string) Warning {
+	return &hintWarning{wrr, hint}
+}
+
+func (wrr *hintWarning) Warn() string {
+	return wrr.wrr.Warn()
+}
+
+func (wrr *hintWarning) Format(state fmt.State, verb rune) {
+	formatState(state, verb, wrr)
+}
+
+func (wrr *hintWarning) String() string {
+	return stringOf(wrr.wrr)
+}
+
+func (wrr *hintWarning) MarshalJSON() ([]byte, error) {
+	return json.Marshal(wrr.wrr)
+}
+
+func (wrr *hintWarning) Hint() string {
+	return wrr.hint
+}
+
+func (wrr *hintWarning) Unwrap() Warning {
+	return wrr.wrr
+}
+
+// HintOf returns the hint of the warning or any warning it wraps.
+// It returns an empty string if there is no hint.
+func HintOf(wrr Warning) string {
+	found, ok := find[interface{ Hint() string }](wrr)
+	if !ok {
+		return ""
+	}
+
+	return found.Hint()
+}
@@ -0,0 +1,65 @@
+package warning_test
+
+import (
+	"testing"
+
+	"go.wamod.dev/warning"
+)
+
+func TestWithNotes(t *testing.T) {
+	inner := warning.New("test")
+	notes := []warning.Note{
+		{Message: "previous definition here", Range: warning.Range{Start: warning.Position{Filename: "a.go", Line: 1, Column: 1}}},
+		{Message: "unpositioned note"},
+	}
+
+	wrr := warning.WithNotes(inner, notes...)
+
+	if wrr.Warn() != "test" {
+		t.Errorf("expected test, got %v", wrr.Warn())
+	}
+
+	if got := warning.Unwrap(wrr); got != inner {
+		t.Errorf("expected %v, got %v", inner, got)
+	}
+
+	got := warning.NotesOf(warning.WithHint(wrr, "hint"))
+	if len(got) != len(notes) {
+		t.Fatalf("expected %v notes, got %v", len(notes), len(got))
+	}
+
+	for i, note := range notes {
+		if got[i] != note {
+			t.Errorf("expected %v, got %v", note, got[i])
+		}
+	}
+
+	if got := warning.NotesOf(inner); got != nil {
+		t.Errorf("expected no notes, got %v", got)
+	}
+
+	requireForwarded(t, wrr, "test")
+}
+
+func TestWithHint(t *testing.T) {
+	inner := warning.New("test")
+	wrr := warning.WithHint(inner, "did you mean X?")
+
+	if wrr.Warn() != "test" {
+		t.Errorf("expected test, got %v", wrr.Warn())
+	}
+
+	if got := warning.Unwrap(wrr); got != inner {
+		t.Errorf("expected %v, got %v", inner, got)
+	}
+
+	if got := warning.HintOf(warning.WithNotes(wrr)); got != "did you mean X?" {
+		t.Errorf("expected did you mean X?, got %v", got)
+	}
+
+	if got := warning.HintOf(inner); got != "" {
+		t.Errorf("expected no hint, got %v", got)
+	}
+
+	requireForwarded(t, wrr, "test")
+}
@@ -0,0 +1,198 @@
+package warning
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// PathElement is a single step of a [Path]. It is either a field name or an index.
+type PathElement struct {
+	// Field is the name of the field. It is only meaningful when IsIndex is false, and may be empty.
+	Field string
+	// Index is the position in a list. It is only meaningful when IsIndex is true.
+	Index int
+	// IsIndex reports whether the element is an index rather than a field name.
+	IsIndex bool
+}
+
+// Path identifies a value inside a nested structure, such as servers[2].tls.cert.
+// Paths are immutable, Field and Index return a new path leaving the original untouched.
+type Path []PathElement
+
+// Field returns a new path with the field name appended.
+func (p Path) Field(name string) Path {
+	return append(p[:len(p):len(p)], PathElement{Field: name})
+}
+
+// Index returns a new path with the index appended.
+func (p Path) Index(i int) Path {
+	return append(p[:len(p):len(p)], PathElement{Index: i, IsIndex: true})
+}
+
+// String returns the dotted form of the path, e.g. servers[2].tls.cert.
+// Field names that are not plain identifiers are quoted, e.g. labels["app.kubernetes.io/name"].
+func (p Path) String() string {
+	var sb strings.Builder
+
+	for _, elem := range p {
+		switch {
+		case elem.IsIndex:
+			sb.WriteByte('[')
+			sb.WriteString(strconv.Itoa(elem.Index))
+			sb.WriteByte(']')
+		case !isPlainField(elem.Field):
+			sb.WriteByte('[')
+			sb.WriteString(strconv.Quote(elem.Field))
+			sb.WriteByte(']')
+		default:
+			if sb.Len() > 0 {
+				sb.WriteByte('.')
+			}
+
+			sb.WriteString(elem.Field)
+		}
+	}
+
+	return sb.String()
+}
+
+// JSONPointer returns the path formatted as a JSON Pointer as defined by RFC 6901,
+// e.g. /servers/2/tls/cert. The empty path is rendered as an empty string.
+func (p Path) JSONPointer() string {
+	var sb strings.Builder
+
+	for _, elem := range p {
+		sb.WriteByte('/')
+
+		if elem.IsIndex {
+			sb.WriteString(strconv.Itoa(elem.Index))
+
+			continue
+		}
+
+		sb.WriteString(pointerEscaper.Replace(elem.Field))
+	}
+
+	return sb.String()
+}
+
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1") //nolint:gochecknoglobals
+
+func isPlainField(name string) bool {
+	if name == "" {
+		return false
+	}
+
+	for _, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_', r == '-':
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+type pathWarning struct {
+	wrr  Warning
+	path Path
+}
+
+// WithPath returns a warning that refers to the value at the given path.
+func WithPath(wrr Warning, path Path) Warning {
+	return &pathWarning{wrr, path}
+}
+
+func (wrr *pathWarning) Warn() string {
+	return wrr.wrr.Warn()
+}
+
+func (wrr *pathWarning) Format(state fmt.State, verb rune) {
+	formatState(state, verb, wrr)
+}
+
+func (wrr *pathWarning) String() string {
+	return stringOf(wrr.wrr)
+}
+
+func (wrr *pathWarning) MarshalJSON() ([]byte, error) {
+	return json.Marshal(wrr.wrr)
+}
+
+func (wrr *pathWarning) Path() Path {
+	return wrr.path
+}
+
+func (wrr *pathWarning) Unwrap() Warning {
+	return wrr.wrr
+}
+
+// PathOf returns the path the warning refers to.
+// It reports false if neither the warning nor any warning it wraps has a path.
+func PathOf(wrr Warning) (Path, bool) {
+	found, ok := find[interface{ Path() Path }](wrr)
+	if !ok {
+		return nil, false
+	}
+
+	return found.Path(), true
+}
+
+type pathKey struct{}
+
+// PathFrom returns the path accumulated in the context by [WithField] and [WithIndex].
+func PathFrom(ctx context.Context) Path {
+	path, _ := ctx.Value(pathKey{}).(Path)
+
+	return path
+}
+
+// WithField returns a new context with the field name appended to its path.
+// Warnings written to the returned context that do not have a path yet are
+// attached the accumulated path using [WithPath].
+func WithField(ctx context.Context, name string) context.Context {
+	return withPath(ctx, PathFrom(ctx).Field(name))
+}
+
+// WithIndex returns a new context with the index appended to its path.
+// Warnings written to the returned context that do not have a path yet are
+// attached the accumulated path using [WithPath].
+func WithIndex(ctx context.Context, i int) context.Context {
+	return withPath(ctx, PathFrom(ctx).Index(i))
+}
+
+func withPath(ctx context.Context, path Path) context.Context {
+	ctx = context.WithValue(ctx, pathKey{}, path)
+
+	writer := getWriter(ctx)
+	if writer == nil {
+		return ctx
+	}
+
+	if found, ok := writer.(*pathWriter); ok {
+		writer = found.next
+	}
+
+	return setWriter(ctx, &pathWriter{writer, path})
+}
+
+type pathWriter struct {
+	next Writer
+	path Path
+}
+
+func (writer *pathWriter) WriteWarning(wrr Warning) error {
+	return writer.WriteWarningContext(context.Background(), wrr)
+}
+
+func (writer *pathWriter) WriteWarningContext(ctx context.Context, wrr Warning) error {
+	if _, ok := PathOf(wrr); !ok {
+		wrr = WithPath(wrr, writer.path)
+	}
+
+	return writeWarning(ctx, writer.next, wrr)
+}
@@ -0,0 +1,184 @@
+package warning_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"go.wamod.dev/warning"
+)
+
+// ExampleWithField demonstrates how to build field paths incrementally using the context.
+func ExampleWithField() {
+	// create a new collector
+	collector := warning.NewCollector()
+	defer collector.Close() // make sure to close the collector when done
+
+	// attach the collector to a context
+	ctx := warning.Attach(context.Background(), collector)
+
+	// nested validators extend the path without threading strings around
+	ctx = warning.WithField(ctx, "servers")
+	ctx = warning.WithIndex(ctx, 2)
+	ctx = warning.WithField(ctx, "tls")
+	warning.Warnf(warning.WithField(ctx, "cert"), "certificate is expired")
+
+	// read all warning from the collector
+	wrrs, err := warning.ReadAll(collector)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, wrr := range wrrs {
+		path, _ := warning.PathOf(wrr)
+		fmt.Printf("%s: %s\n", path, wrr.Warn())
+		fmt.Printf("%s: %s\n", path.JSONPointer(), wrr.Warn())
+	}
+
+	// Output:
+	// servers[2].tls.cert: certificate is expired
+	// /servers/2/tls/cert: certificate is expired
+}
+
+func TestPath_String(t *testing.T) {
+	tests := []struct {
+		path warning.Path
+		want string
+	}{
+		{nil, ""},
+		{warning.Path{}.Field("servers"), "servers"},
+		{warning.Path{}.Field("servers").Index(2).Field("tls").Field("cert"), "servers[2].tls.cert"},
+		{warning.Path{}.Index(0).Field("name"), "[0].name"},
+		{warning.Path{}.Field("labels").Field("app.io/name"), `labels["app.io/name"]`},
+		{warning.Path{}.Field("max-size"), "max-size"},
+		{warning.Path{}.Field("labels").Field(""), `labels[""]`},
+		{warning.Path{}.Index(0), "[0]"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.path.String(); got != tt.want {
+			t.Errorf("expected %q, got %q", tt.want, got)
+		}
+	}
+}
+
+func TestPath_JSONPointer(t *testing.T) {
+	tests := []struct {
+		path warning.Path
+		want string
+	}{
+		{nil, ""},
+		{warning.Path{}.Field("servers").Index(2).Field("tls"), "/servers/2/tls"},
+		{warning.Path{}.Field("a/b").Field("m~n"), "/a~1b/m~0n"},
+		{warning.Path{}.Field(""), "/"},
+		{warning.Path{}.Field("").Index(0), "//0"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.path.JSONPointer(); got != tt.want {
+			t.Errorf("expected %q, got %q", tt.want, got)
+		}
+	}
+}
+
+func TestPath_Immutable(t *testing.T) {
+	base := warning.Path{}.Field("servers")
+	first := base.Index(1)
+	second := base.Index(2)
+
+	if got := first.String(); got != "servers[1]" {
+		t.Errorf("expected servers[1], got %v", got)
+	}
+
+	if got := second.String(); got != "servers[2]" {
+		t.Errorf("expected servers[2], got %v", got)
+	}
+
+	if got := base.String(); got != "servers" {
+		t.Errorf("expected servers, got %v", got)
+	}
+}
+
+func TestWithPath(t *testing.T) {
+	inner := warning.New("test")
+	want := warning.Path{}.Field("name")
+	wrr := warning.WithPath(inner, want)
+
+	if wrr.Warn() != "test" {
+		t.Errorf("expected test, got %v", wrr.Warn())
+	}
+
+	if got := warning.Unwrap(wrr); got != inner {
+		t.Errorf("expected %v, got %v", inner, got)
+	}
+
+	if got, ok := warning.PathOf(wrr); !ok || got.String() != want.String() {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	if _, ok := warning.PathOf(inner); ok {
+		t.Errorf("expected no path")
+	}
+
+	requireForwarded(t, wrr, "test")
+}
+
+// requireForwarded checks that the wrapper prints and encodes to JSON as the message it wraps.
+func requireForwarded(t *testing.T, wrr warning.Warning, want string) {
+	t.Helper()
+
+	if s, ok := wrr.(fmt.Stringer); !ok || s.String() != want {
+		t.Errorf("expected %T to be a fmt.Stringer returning %v", wrr, want)
+	}
+
+	data, err := json.Marshal(wrr)
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	if got, _ := json.Marshal(want); string(data) != string(got) {
+		t.Errorf("expected %s, got %s", got, data)
+	}
+}
+
+func TestWithField(t *testing.T) {
+	writer := &mockWriter{}
+
+	ctx := warning.Attach(context.Background(), writer)
+	ctx = warning.WithField(ctx, "servers")
+	ctx = warning.WithIndex(ctx, 2)
+
+	warning.Warn(ctx, warning.New("test-1"))
+	warning.Warn(warning.WithField(ctx, "port"), warning.New("test-2"))
+	warning.Warn(ctx, warning.WithPath(warning.New("test-3"), warning.Path{}.Field("other")))
+
+	want := []string{"servers[2]", "servers[2].port", "other"}
+
+	if len(writer.buf) != len(want) {
+		t.Fatalf("expected %v warnings, got %v", len(want), len(writer.buf))
+	}
+
+	for i, wrr := range writer.buf {
+		if got, _ := warning.PathOf(wrr); got.String() != want[i] {
+			t.Errorf("expected %v, got %v", want[i], got)
+		}
+	}
+
+	if got := warning.PathFrom(ctx).String(); got != "servers[2]" {
+		t.Errorf("expected servers[2], got %v", got)
+	}
+}
+
+func TestWithFieldNoWriter(t *testing.T) {
+	ctx := warning.WithField(context.Background(), "servers")
+
+	if got := warning.PathFrom(ctx).String(); got != "servers" {
+		t.Errorf("expected servers, got %v", got)
+	}
+
+	err := warning.Warn(ctx, warning.New("test"))
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+}
@@ -0,0 +1,535 @@
+package warning
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// PolicyAction tells what happens to a warning matched by a [PolicyRule].
+type PolicyAction string
+
+const (
+	// PolicyKeep writes the matched warning, possibly with a new severity or to another sink.
+	PolicyKeep PolicyAction = "keep"
+	// PolicyDrop silences the matched warning.
+	PolicyDrop PolicyAction = "drop"
+)
+
+// PolicyMatch selects the warnings a [PolicyRule] applies to.
+// All non-empty fields must match, an empty PolicyMatch matches every warning.
+type PolicyMatch struct {
+	// Code matches the warning code using a [path.Match] pattern, e.g. W1*.
+	Code string `json:"code,omitempty"`
+	// Severity matches the warning severity. It is a severity name optionally prefixed
+	// with one of the =, !=, <, <=, > or >= operators, e.g. >=warning.
+	Severity string `json:"severity,omitempty"`
+	// Message matches the warning message using a regular expression.
+	Message string `json:"message,omitempty"`
+	// Scope matches the warning scope and its nested scopes using a [path.Match] pattern.
+	Scope string `json:"scope,omitempty"`
+	// Tag matches warnings that have the tag.
+	Tag Tag `json:"tag,omitempty"`
+}
+
+// PolicyRule describes how to handle the warnings it matches.
+type PolicyRule struct {
+	// Match selects the warnings the rule applies to.
+	Match PolicyMatch `json:"match"`
+	// Action tells whether matched warnings are kept or dropped. Empty means [PolicyKeep].
+	Action PolicyAction `json:"action,omitempty"`
+	// Severity, when set, replaces the severity of matched warnings, e.g. to escalate them to errors.
+	Severity string `json:"severity,omitempty"`
+	// Sink, when set, routes matched warnings to the named sink instead of the attached writer.
+	Sink string `json:"sink,omitempty"`
+}
+
+// Policy is a validated, ordered list of rules. A warning is handled by the first rule it matches,
+// warnings that match no rule are written unchanged.
+//
+// Policies are usually loaded from JSON documents:
+//
+//	{
+//	  "version": 1,
+//	  "rules": [
+//	    {"match": {"code": "W12"}, "action": "drop"},
+//	    {"match": {"code": "W7"}, "severity": "error"},
+//	    {"match": {"code": "W3"}, "severity": "info"},
+//	    {"match": {"tag": "deprecation"}, "sink": "deprecations"}
+//	  ]
+//	}
+type Policy struct {
+	rules []policyRule
+}
+
+type policyRule struct {
+	spec     PolicyRule
+	severity func(Severity) bool
+	message  *regexp.Regexp
+	set      *Severity
+}
+
+// NewPolicy validates the rules and returns a new Policy.
+// Validation errors are reported as [*PolicyError].
+func NewPolicy(rules ...PolicyRule) (*Policy, error) {
+	compiled := make([]policyRule, 0, len(rules))
+
+	for i, rule := range rules {
+		c, err := compileRule(rule)
+		if err != nil {
+			err.Rule = i
+
+			return nil, err
+		}
+
+		compiled = append(compiled, c)
+	}
+
+	return &Policy{compiled}, nil
+}
+
+// ParsePolicy parses and validates a JSON policy document.
+func ParsePolicy(data []byte) (*Policy, error) {
+	var doc struct {
+		Version int               `json:"version"`
+		Rules   []json.RawMessage `json:"rules"`
+	}
+
+	if err := decodeStrict(data, &doc); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPolicy, jsonPosition(data, 0, err))
+	}
+
+	if doc.Version > 1 {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidPolicy, doc.Version)
+	}
+
+	rules := make([]PolicyRule, len(doc.Rules))
+	offsets := ruleOffsets(data)
+
+	for i, raw := range doc.Rules {
+		if err := decodeStrict(raw, &rules[i]); err != nil {
+			var base int64
+			if i < len(offsets) {
+				base = offsets[i]
+			}
+
+			return nil, &PolicyError{Rule: i, Err: jsonPosition(data, base, err)}
+		}
+	}
+
+	return NewPolicy(rules...)
+}
+
+// ruleOffsets returns the offset in data of each rule of a policy document that was already decoded,
+// so that errors in a rule are reported at their own position, even if other rules are identical.
+func ruleOffsets(data []byte) []int64 {
+	dec := json.NewDecoder(bytes.NewReader(data))
+
+	if _, err := dec.Token(); err != nil {
+		return nil
+	}
+
+	var offsets []int64
+
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return offsets
+		}
+
+		// like json.Unmarshal, match the key case-insensitively and keep the last one
+		if name, _ := key.(string); !strings.EqualFold(name, "rules") {
+			if err := dec.Decode(new(json.RawMessage)); err != nil {
+				return offsets
+			}
+
+			continue
+		}
+
+		if tok, err := dec.Token(); err != nil || tok != json.Delim('[') {
+			return offsets
+		}
+
+		offsets = offsets[:0]
+
+		for dec.More() {
+			// the decoder stops before the separator and the spaces preceding the rule
+			offset := dec.InputOffset()
+			for offset < int64(len(data)) && strings.IndexByte(" \t\r\n,", data[offset]) >= 0 {
+				offset++
+			}
+
+			offsets = append(offsets, offset)
+
+			if err := dec.Decode(new(json.RawMessage)); err != nil {
+				return offsets
+			}
+		}
+
+		if _, err := dec.Token(); err != nil {
+			return offsets
+		}
+	}
+
+	return offsets
+}
+
+// Policy returns the policy itself. It implements [PolicySource].
+func (p *Policy) Policy() *Policy {
+	return p
+}
+
+// Rules returns the rules of the policy.
+func (p *Policy) Rules() []PolicyRule {
+	rules := make([]PolicyRule, 0, len(p.rules))
+	for _, rule := range p.rules {
+		rules = append(rules, rule.spec)
+	}
+
+	return rules
+}
+
+// Apply evaluates the policy for the warning. It returns the warning to write,
+// the name of the sink to write it to (empty for the attached writer), and whether it is kept at all.
+func (p *Policy) Apply(wrr Warning) (_ Warning, sink string, keep bool) {
+	for _, rule := range p.rules {
+		if !rule.matches(wrr) {
+			continue
+		}
+
+		if rule.spec.Action == PolicyDrop {
+			return nil, "", false
+		}
+
+		if rule.set != nil {
+			wrr = WithSeverity(wrr, *rule.set)
+		}
+
+		return wrr, rule.spec.Sink, true
+	}
+
+	return wrr, "", true
+}
+
+func compileRule(rule PolicyRule) (policyRule, *PolicyError) {
+	compiled := policyRule{spec: rule}
+
+	if _, err := path.Match(rule.Match.Code, ""); err != nil {
+		return compiled, &PolicyError{Field: "match.code", Err: err}
+	}
+
+	if _, err := path.Match(rule.Match.Scope, ""); err != nil {
+		return compiled, &PolicyError{Field: "match.scope", Err: err}
+	}
+
+	if rule.Match.Severity != "" {
+		cmp, err := parseSeverityMatch(rule.Match.Severity)
+		if err != nil {
+			return compiled, &PolicyError{Field: "match.severity", Err: err}
+		}
+
+		compiled.severity = cmp
+	}
+
+	if rule.Match.Message != "" {
+		re, err := regexp.Compile(rule.Match.Message)
+		if err != nil {
+			return compiled, &PolicyError{Field: "match.message", Err: err}
+		}
+
+		compiled.message = re
+	}
+
+	switch rule.Action {
+	case "", PolicyKeep:
+	case PolicyDrop:
+		if rule.Severity != "" || rule.Sink != "" {
+			return compiled, &PolicyError{Field: "action", Err: errors.New("dropped warnings cannot have a severity or a sink")} //nolint:err113
+		}
+	default:
+		return compiled, &PolicyError{Field: "action", Err: fmt.Errorf("unknown action %q", rule.Action)} //nolint:err113
+	}
+
+	if rule.Severity != "" {
+		severity, err := ParseSeverity(rule.Severity)
+		if err != nil {
+			return compiled, &PolicyError{Field: "severity", Err: err}
+		}
+
+		compiled.set = &severity
+	}
+
+	return compiled, nil
+}
+
+func (rule *policyRule) matches(wrr Warning) bool {
+	match := rule.spec.Match
+
+	if match.Code != "" {
+		if ok, _ := path.Match(match.Code, CodeOf(wrr)); !ok {
+			return false
+		}
+	}
+
+	if match.Scope != "" && !matchScope(match.Scope, ScopeOf(wrr)) {
+		return false
+	}
+
+	if match.Tag != "" && !HasTag(wrr, match.Tag) {
+		return false
+	}
+
+	if rule.severity != nil && !rule.severity(SeverityOf(wrr)) {
+		return false
+	}
+
+	if rule.message != nil && !rule.message.MatchString(wrr.Warn()) {
+		return false
+	}
+
+	return true
+}
+
+// matchScope reports whether the scope or any of its parents matches the pattern.
+func matchScope(pattern, scope string) bool {
+	for scope != "" {
+		if ok, _ := path.Match(pattern, scope); ok {
+			return true
+		}
+
+		i := strings.LastIndexByte(scope, '.')
+		if i < 0 {
+			break
+		}
+
+		scope = scope[:i]
+	}
+
+	return false
+}
+
+func parseSeverityMatch(s string) (func(Severity) bool, error) {
+	s = strings.TrimSpace(s)
+
+	i := strings.IndexFunc(s, func(r rune) bool { return !strings.ContainsRune("=!<>", r) })
+	if i < 0 {
+		i = len(s)
+	}
+
+	severity, err := ParseSeverity(strings.TrimSpace(s[i:]))
+	if err != nil {
+		return nil, err
+	}
+
+	return compareSeverity(s[:i], severity)
+}
+
+func compareSeverity(op string, severity Severity) (func(Severity) bool, error) {
+	switch op {
+	case "", "=", "==":
+		return func(s Severity) bool { return s == severity }, nil
+	case "!=":
+		return func(s Severity) bool { return s != severity }, nil
+	case "<":
+		return func(s Severity) bool { return s < severity }, nil
+	case "<=":
+		return func(s Severity) bool { return s <= severity }, nil
+	case ">":
+		return func(s Severity) bool { return s > severity }, nil
+	case ">=":
+		return func(s Severity) bool { return s >= severity }, nil
+	default:
+		return nil, fmt.Errorf("unknown operator %q", op) //nolint:err113
+	}
+}
+
+func decodeStrict(data []byte, v any) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+
+	return dec.Decode(v)
+}
+
+// jsonPosition annotates JSON syntax and type errors with the line and column they occurred at.
+// The error offset is relative to base in data.
+func jsonPosition(data []byte, base int64, err error) error {
+	var (
+		syntaxErr *json.SyntaxError
+		typeErr   *json.UnmarshalTypeError
+		offset    int64
+	)
+
+	switch {
+	case errors.As(err, &syntaxErr):
+		offset = syntaxErr.Offset
+	case errors.As(err, &typeErr):
+		offset = typeErr.Offset
+	default:
+		return err
+	}
+
+	pos, _ := resolve(data, Position{Offset: int(min(base+offset, int64(len(data))))})
+
+	return fmt.Errorf("line %d, column %d: %w", pos.Line, pos.Column, err)
+}
+
+// PolicySource provides the policy currently in effect. It is implemented by [*Policy] and [*PolicyFile].
+type PolicySource interface {
+	Policy() *Policy
+}
+
+// ApplyPolicy returns a new context that applies the policy provided by src to each written warning.
+// Warnings routed to a sink are written to the writer of that name in sinks instead of the attached writer.
+//
+// If a rule of the policy routes warnings to a sink missing from sinks, ctx is returned unchanged along with
+// an error wrapping [ErrUnknownSink]. Policies that change later, such as a reloaded [PolicyFile], are not
+// checked again: writing a warning routed to a missing sink returns ErrUnknownSink.
+func ApplyPolicy(ctx context.Context, src PolicySource, sinks map[string]Writer) (context.Context, error) {
+	for i, rule := range src.Policy().rules {
+		if _, ok := sinks[rule.spec.Sink]; rule.spec.Sink != "" && !ok {
+			return ctx, fmt.Errorf("%w: rules[%d]: %s", ErrUnknownSink, i, rule.spec.Sink)
+		}
+	}
+
+	writer := getWriter(ctx)
+	if writer == nil && len(sinks) == 0 {
+		return ctx, nil
+	}
+
+	return setWriter(ctx, &policyWriter{writer, src, maps.Clone(sinks)}), nil
+}
+
+type policyWriter struct {
+	next  Writer
+	src   PolicySource
+	sinks map[string]Writer
+}
+
+func (writer *policyWriter) WriteWarning(wrr Warning) error {
+	return writer.WriteWarningContext(context.Background(), wrr)
+}
+
+func (writer *policyWriter) WriteWarningContext(ctx context.Context, wrr Warning) error {
+	wrr, sink, keep := writer.src.Policy().Apply(wrr)
+
+	switch {
+	case !keep:
+		return nil
+	case sink != "":
+		found, ok := writer.sinks[sink]
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrUnknownSink, sink)
+		}
+
+		return writeWarning(ctx, found, wrr)
+	case writer.next == nil:
+		return nil
+	default:
+		return writeWarning(ctx, writer.next, wrr)
+	}
+}
+
+// PolicyFile is a [PolicySource] backed by a JSON policy file that can be reloaded while in use.
+// It is safe for concurrent use.
+type PolicyFile struct {
+	name    string
+	policy  atomic.Pointer[Policy]
+	mtx     sync.Mutex
+	modTime time.Time
+	size    int64
+}
+
+// OpenPolicyFile loads the policy from the named file.
+func OpenPolicyFile(name string) (*PolicyFile, error) {
+	f := &PolicyFile{name: name}
+	if err := f.Reload(); err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// Policy returns the policy currently in effect.
+func (f *PolicyFile) Policy() *Policy {
+	return f.policy.Load()
+}
+
+// Reload reads and validates the policy file again. If the file is invalid,
+// the previous policy stays in effect and the error is returned.
+func (f *PolicyFile) Reload() error {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	info, err := os.Stat(f.name)
+	if err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(f.name)
+	if err != nil {
+		return err
+	}
+
+	// remember the file version even if it is invalid, so it is not reported again until changed
+	f.modTime, f.size = info.ModTime(), info.Size()
+
+	policy, err := ParsePolicy(data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", f.name, err)
+	}
+
+	f.policy.Store(policy)
+
+	return nil
+}
+
+func (f *PolicyFile) changed() bool {
+	info, err := os.Stat(f.name)
+
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	if err != nil {
+		// report a missing file once, then wait for it to come back
+		missing := f.size < 0
+		f.modTime, f.size = time.Time{}, -1
+
+		return !missing
+	}
+
+	return !info.ModTime().Equal(f.modTime) || info.Size() != f.size
+}
+
+// Watch polls the policy file at the given interval and reloads it when it changes.
+// It blocks until ctx is done. Reload errors are written as warnings to ctx
+// and the previous policy stays in effect.
+func (f *PolicyFile) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if !f.changed() {
+			continue
+		}
+
+		if err := f.Reload(); err != nil {
+			Warn(ctx, New(err.Error()))
+		}
+	}
+}
@@ -0,0 +1,354 @@
+package warning_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"go.wamod.dev/warning"
+)
+
+// ExampleApplyPolicy demonstrates how to change warning handling with a policy document.
+func ExampleApplyPolicy() {
+	policy, err := warning.ParsePolicy([]byte(`{
+		"version": 1,
+		"rules": [
+			{"match": {"code": "W12"}, "action": "drop"},
+			{"match": {"code": "W7"}, "severity": "error"},
+			{"match": {"tag": "deprecation"}, "sink": "deprecations"}
+		]
+	}`))
+	if err != nil {
+		panic(err)
+	}
+
+	// create collectors for regular warnings and deprecations
+	collector := warning.NewCollector()
+	defer collector.Close()
+
+	deprecations := warning.NewCollector()
+	defer deprecations.Close()
+
+	// attach the collector and apply the policy
+	ctx := warning.Attach(context.Background(), collector)
+	ctx, err = warning.ApplyPolicy(ctx, policy, map[string]warning.Writer{
+		"deprecations": deprecations,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	warning.Warn(ctx,
+		warning.WithCode(warning.New("silenced"), "W12"),
+		warning.WithCode(warning.New("escalated"), "W7"),
+		warning.WithTags(warning.New("deprecated"), warning.TagDeprecation),
+		warning.New("unchanged"),
+	)
+
+	for _, r := range []warning.Reader{collector, deprecations} {
+		wrrs, err := warning.ReadAll(r)
+		if err != nil {
+			panic(err)
+		}
+
+		for _, wrr := range wrrs {
+			fmt.Printf("%s: %s\n", warning.SeverityOf(wrr), wrr.Warn())
+		}
+	}
+
+	// Output:
+	// error: escalated
+	// warning: unchanged
+	// warning: deprecated
+}
+
+func TestPolicy_Apply(t *testing.T) {
+	policy, err := warning.NewPolicy(
+		warning.PolicyRule{Match: warning.PolicyMatch{Scope: "db"}, Severity: "info"},
+		warning.PolicyRule{Match: warning.PolicyMatch{Message: "^legacy"}, Action: warning.PolicyDrop},
+		warning.PolicyRule{Match: warning.PolicyMatch{Severity: ">=error"}, Sink: "errors"},
+		warning.PolicyRule{Match: warning.PolicyMatch{Code: "W1*"}, Severity: "error"},
+	)
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		wrr      warning.Warning
+		keep     bool
+		sink     string
+		severity warning.Severity
+	}{
+		{"no match", warning.New("test"), true, "", warning.SeverityWarning},
+		{"nested scope", scoped("db.migrations", warning.New("test")), true, "", warning.SeverityInfo},
+		{"other scope", scoped("dbx", warning.New("test")), true, "", warning.SeverityWarning},
+		{"message", warning.New("legacy option"), false, "", 0},
+		{"severity", warning.WithSeverity(warning.New("test"), warning.SeverityError), true, "errors", warning.SeverityError},
+		{"code", warning.WithCode(warning.New("test"), "W12"), true, "", warning.SeverityError},
+		{"first rule wins", scoped("db", warning.New("legacy")), true, "", warning.SeverityInfo},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wrr, sink, keep := policy.Apply(tt.wrr)
+
+			if keep != tt.keep {
+				t.Fatalf("expected keep %v, got %v", tt.keep, keep)
+			}
+
+			if !keep {
+				return
+			}
+
+			if sink != tt.sink {
+				t.Errorf("expected sink %q, got %q", tt.sink, sink)
+			}
+
+			if got := warning.SeverityOf(wrr); got != tt.severity {
+				t.Errorf("expected severity %v, got %v", tt.severity, got)
+			}
+		})
+	}
+
+	if got := len(policy.Rules()); got != 4 {
+		t.Errorf("expected 4 rules, got %v", got)
+	}
+}
+
+func scoped(scope string, wrr warning.Warning) warning.Warning {
+	writer := &mockWriter{}
+	ctx := warning.WithScope(warning.Attach(context.Background(), writer), scope)
+
+	warning.Warn(ctx, wrr)
+
+	return writer.buf[0]
+}
+
+func TestParsePolicy_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		doc   string
+		rule  int
+		field string
+		text  string
+	}{
+		{"syntax", "{\n  \"rules\": [\n    {\"match\": {}},,\n  ]\n}", -1, "", "line 3, column 20"},
+		{"version", `{"version": 2}`, -1, "", "unsupported version 2"},
+		{"unknown field", `{"rules": [{}, {"match": {"codes": "W1"}}]}`, 1, "", `unknown field "codes"`},
+		{"type", "{\"rules\": [\n{\"match\": {\"code\": 1}}]}", 0, "", "line 2, column 21"},
+		{"repeated text", "{\"version\": 1, \"rules\": [\n  {},\n  1\n]}", 1, "", "line 3, column 4"},
+		{"severity", `{"rules": [{"severity": "fatal"}]}`, 0, "severity", `unknown severity: "fatal"`},
+		{"match severity", `{"rules": [{}, {}, {"match": {"severity": "=>error"}}]}`, 2, "match.severity", `unknown operator "=>"`},
+		{"message", `{"rules": [{"match": {"message": "("}}]}`, 0, "match.message", "missing closing )"},
+		{"code", `{"rules": [{"match": {"code": "W["}}]}`, 0, "match.code", "syntax error in pattern"},
+		{"action", `{"rules": [{"action": "mute"}]}`, 0, "action", `unknown action "mute"`},
+		{"drop", `{"rules": [{"action": "drop", "sink": "x"}]}`, 0, "action", "cannot have a severity or a sink"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := warning.ParsePolicy([]byte(tt.doc))
+			if !errors.Is(err, warning.ErrInvalidPolicy) {
+				t.Fatalf("expected %v, got %v", warning.ErrInvalidPolicy, err)
+			}
+
+			if !strings.Contains(err.Error(), tt.text) {
+				t.Errorf("expected error to contain %q, got %v", tt.text, err)
+			}
+
+			var policyErr *warning.PolicyError
+
+			if !errors.As(err, &policyErr) {
+				if tt.rule >= 0 {
+					t.Fatalf("expected policy error, got %v", err)
+				}
+
+				return
+			}
+
+			if policyErr.Rule != tt.rule || policyErr.Field != tt.field {
+				t.Errorf("expected rules[%d].%s, got rules[%d].%s", tt.rule, tt.field, policyErr.Rule, policyErr.Field)
+			}
+		})
+	}
+}
+
+func TestApplyPolicy_UnknownSink(t *testing.T) {
+	policy, err := warning.NewPolicy(warning.PolicyRule{Match: warning.PolicyMatch{Code: "W1"}}, warning.PolicyRule{Sink: "missing"})
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	ctx := warning.Attach(context.Background(), &mockWriter{})
+
+	got, err := warning.ApplyPolicy(ctx, policy, map[string]warning.Writer{"other": &mockWriter{}})
+	if !errors.Is(err, warning.ErrUnknownSink) || !strings.Contains(err.Error(), "rules[1]: missing") {
+		t.Errorf("expected %v for rules[1], got %v", warning.ErrUnknownSink, err)
+	}
+
+	if got != ctx {
+		t.Errorf("expected same context, got %v", got)
+	}
+}
+
+func TestApplyPolicy_ReloadedUnknownSink(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "policy.json")
+
+	if err := os.WriteFile(name, []byte(`{"rules": []}`), 0o600); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	file, err := warning.OpenPolicyFile(name)
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	ctx, err := warning.ApplyPolicy(warning.Attach(context.Background(), &mockWriter{}), file, nil)
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	// sinks added by a reload are only checked when warnings are written
+	if err := os.WriteFile(name, []byte(`{"rules": [{"sink": "missing"}]}`), 0o600); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	if err := file.Reload(); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	if err := warning.Warn(ctx, warning.New("test")); !errors.Is(err, warning.ErrUnknownSink) {
+		t.Errorf("expected %v, got %v", warning.ErrUnknownSink, err)
+	}
+}
+
+func TestApplyPolicyNoWriter(t *testing.T) {
+	policy, err := warning.NewPolicy()
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	ctx, err := warning.ApplyPolicy(context.Background(), policy, nil)
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	if ctx != context.Background() {
+		t.Errorf("expected same context, got %v", ctx)
+	}
+
+	sink := &mockWriter{}
+	ctx, err = warning.ApplyPolicy(context.Background(), policy, map[string]warning.Writer{"sink": sink})
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	if err := warning.Warn(ctx, warning.New("test")); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	if len(sink.buf) > 0 {
+		t.Errorf("expected no warnings in sink, got %v", sink.buf)
+	}
+}
+
+func TestPolicyFile(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "policy.json")
+
+	write := func(doc string, mtime time.Time) {
+		if err := os.WriteFile(name, []byte(doc), 0o600); err != nil {
+			t.Fatalf("expected nil error, got %v", err)
+		}
+
+		if err := os.Chtimes(name, mtime, mtime); err != nil {
+			t.Fatalf("expected nil error, got %v", err)
+		}
+	}
+
+	now := time.Now()
+	write(`{"rules": [{"action": "drop"}]}`, now)
+
+	file, err := warning.OpenPolicyFile(name)
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	collector := warning.NewCollector()
+	defer collector.Close()
+
+	ctx, cancel := context.WithCancel(warning.Attach(context.Background(), collector))
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		file.Watch(ctx, time.Millisecond)
+	}()
+
+	writer := &mockWriter{}
+	policyCtx, err := warning.ApplyPolicy(warning.Attach(context.Background(), writer), file, nil)
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	warning.Warn(policyCtx, warning.New("dropped"))
+
+	if len(writer.buf) > 0 {
+		t.Errorf("expected no warnings, got %v", writer.buf)
+	}
+
+	// an invalid update is reported and the previous policy stays in effect
+	write(`{"rules": [{"action": "mute"}]}`, now.Add(time.Second))
+
+	var reported warning.Warning
+
+	waitFor(t, func() bool {
+		reported, _ = collector.ReadWarning()
+
+		return reported != nil
+	})
+	cancel()
+	<-done
+
+	if got := reported.Warn(); !strings.Contains(got, `unknown action "mute"`) {
+		t.Errorf("expected reload error, got %v", got)
+	}
+
+	if _, _, keep := file.Policy().Apply(warning.New("test")); keep {
+		t.Errorf("expected previous policy to stay in effect")
+	}
+
+	write(`{"rules": []}`, now.Add(2*time.Second))
+
+	if err := file.Reload(); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	if _, _, keep := file.Policy().Apply(warning.New("test")); !keep {
+		t.Errorf("expected new policy to be in effect")
+	}
+
+	if _, err := warning.OpenPolicyFile(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Errorf("expected error for a missing file")
+	}
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("condition not met in time")
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,167 @@
+package warning
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	goscanner "go/scanner"
+	"go/token"
+)
+
+// Position describes a location in a source file. It is an alias of [token.Position],
+// so positions produced by go/token, go/scanner and go/parser can be used directly.
+// Line and Column are 1-based, Column is counted in bytes. A position is valid if its Line is positive.
+type Position = token.Position
+
+// Range describes a span of source text starting at Start and ending before End.
+// End is optional, a range with an invalid End refers to a single position.
+type Range struct {
+	Start Position
+	End   Position
+}
+
+// String returns the range in the form file:line:column or file:line:column-line:column.
+func (r Range) String() string {
+	if !r.End.IsValid() || r.End == r.Start {
+		return r.Start.String()
+	}
+
+	return fmt.Sprintf("%s-%d:%d", r.Start, r.End.Line, r.End.Column)
+}
+
+type rangeWarning struct {
+	wrr Warning
+	rng Range
+}
+
+// WithPosition returns a warning that refers to the given source position.
+func WithPosition(wrr Warning, pos Position) Warning {
+	return &rangeWarning{wrr, Range{Start: pos}}
+}
+
+// WithRange returns a warning that refers to the given source range.
+func WithRange(wrr Warning, rng Range) Warning {
+	return &rangeWarning{wrr, rng}
+}
+
+func (wrr *rangeWarning) Warn() string {
+	return wrr.wrr.Warn()
+}
+
+func (wrr *rangeWarning) Format(state fmt.State, verb rune) {
+	formatState(state, verb, wrr)
+}
+
+func (wrr *rangeWarning) String() string {
+	return stringOf(wrr.wrr)
+}
+
+func (wrr *rangeWarning) MarshalJSON() ([]byte, error) {
+	return json.Marshal(wrr.wrr)
+}
+
+func (wrr *rangeWarning) Range() Range {
+	return wrr.rng
+}
+
+func (wrr *rangeWarning) Unwrap() Warning {
+	return wrr.wrr
+}
+
+// RangeOf returns the source range the warning refers to.
+// It reports false if neither the warning nor any warning it wraps has a range.
+func RangeOf(wrr Warning) (Range, bool) {
+	found, ok := find[interface{ Range() Range }](wrr)
+	if !ok {
+		return Range{}, false
+	}
+
+	return found.Range(), true
+}
+
+// PositionOf returns the start position of the source range the warning refers to.
+// It reports false if neither the warning nor any warning it wraps has a range.
+func PositionOf(wrr Warning) (Position, bool) {
+	rng, ok := RangeOf(wrr)
+
+	return rng.Start, ok
+}
+
+// FromScannerError converts a [go/scanner.Error] into a positioned warning.
+func FromScannerError(err *goscanner.Error) Warning {
+	return WithPosition(New(err.Msg), err.Pos)
+}
+
+// FromErrorList converts each entry of a [go/scanner.ErrorList] into a positioned warning.
+func FromErrorList(list goscanner.ErrorList) []Warning {
+	wrrs := make([]Warning, 0, len(list))
+
+	for _, err := range list {
+		wrrs = append(wrrs, FromScannerError(err))
+	}
+
+	return wrrs
+}
+
+// lineBounds returns the byte offsets of the start and the end (excluding the newline) of the 1-based line.
+func lineBounds(src []byte, line int) (start, end int, ok bool) {
+	if line < 1 {
+		return 0, 0, false
+	}
+
+	for n := 1; n < line; n++ {
+		i := bytes.IndexByte(src[start:], '\n')
+		if i < 0 {
+			return 0, 0, false
+		}
+
+		start += i + 1
+	}
+
+	end = len(src)
+	if i := bytes.IndexByte(src[start:], '\n'); i >= 0 {
+		end = start + i
+	}
+
+	return start, end, true
+}
+
+// offsetOf resolves the byte offset of the position in src.
+// Line and column take precedence, the Offset field is used for positions without a line.
+func offsetOf(src []byte, pos Position) (int, bool) {
+	if !pos.IsValid() {
+		if pos.Offset < 0 || pos.Offset > len(src) {
+			return 0, false
+		}
+
+		return pos.Offset, true
+	}
+
+	start, end, ok := lineBounds(src, pos.Line)
+	if !ok {
+		return 0, false
+	}
+
+	col := max(pos.Column, 1) - 1
+	if start+col > end {
+		return 0, false
+	}
+
+	return start + col, true
+}
+
+// resolve fills in the line and column of a position that only has an offset.
+func resolve(src []byte, pos Position) (Position, bool) {
+	if pos.IsValid() {
+		return pos, true
+	}
+
+	if pos.Offset < 0 || pos.Offset > len(src) {
+		return pos, false
+	}
+
+	pos.Line = bytes.Count(src[:pos.Offset], []byte{'\n'}) + 1
+	pos.Column = pos.Offset - (bytes.LastIndexByte(src[:pos.Offset], '\n') + 1) + 1
+
+	return pos, true
+}
@@ -0,0 +1,92 @@
+package warning_test
+
+import (
+	"go/scanner"
+	"go/token"
+	"testing"
+
+	"go.wamod.dev/warning"
+)
+
+func TestRange_String(t *testing.T) {
+	tests := []struct {
+		rng  warning.Range
+		want string
+	}{
+		{warning.Range{}, "-"},
+		{warning.Range{Start: warning.Position{Filename: "a.toml", Line: 3, Column: 2}}, "a.toml:3:2"},
+		{
+			warning.Range{
+				Start: warning.Position{Filename: "a.toml", Line: 3, Column: 2},
+				End:   warning.Position{Filename: "a.toml", Line: 3, Column: 9},
+			},
+			"a.toml:3:2-3:9",
+		},
+	}
+
+	for _, tt := range tests {
+		if got := tt.rng.String(); got != tt.want {
+			t.Errorf("expected %q, got %q", tt.want, got)
+		}
+	}
+}
+
+func TestWithPosition(t *testing.T) {
+	inner := warning.New("test")
+	pos := token.Position{Filename: "config.toml", Line: 12, Column: 5}
+	wrr := warning.WithPosition(inner, pos)
+
+	if wrr.Warn() != "test" {
+		t.Errorf("expected test, got %v", wrr.Warn())
+	}
+
+	if got := warning.Unwrap(wrr); got != inner {
+		t.Errorf("expected %v, got %v", inner, got)
+	}
+
+	if got, ok := warning.PositionOf(wrr); !ok || got != pos {
+		t.Errorf("expected %v, got %v", pos, got)
+	}
+
+	if _, ok := warning.PositionOf(inner); ok {
+		t.Errorf("expected no position")
+	}
+}
+
+func TestWithRange(t *testing.T) {
+	want := warning.Range{
+		Start: warning.Position{Filename: "config.toml", Line: 1, Column: 1},
+		End:   warning.Position{Filename: "config.toml", Line: 1, Column: 4},
+	}
+
+	wrr := warning.WithPath(warning.WithRange(warning.New("test"), want), nil)
+
+	if got, ok := warning.RangeOf(wrr); !ok || got != want {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	requireForwarded(t, warning.WithRange(warning.New("test"), want), "test")
+}
+
+func TestFromErrorList(t *testing.T) {
+	var list scanner.ErrorList
+
+	list.Add(token.Position{Filename: "main.go", Line: 1, Column: 2}, "error-1")
+	list.Add(token.Position{Filename: "main.go", Line: 3, Column: 4}, "error-2")
+
+	wrrs := warning.FromErrorList(list)
+
+	if len(wrrs) != 2 {
+		t.Fatalf("expected 2 warnings, got %v", len(wrrs))
+	}
+
+	for i, wrr := range wrrs {
+		if wrr.Warn() != list[i].Msg {
+			t.Errorf("expected %v, got %v", list[i].Msg, wrr.Warn())
+		}
+
+		if got, _ := warning.PositionOf(wrr); got != list[i].Pos {
+			t.Errorf("expected %v, got %v", list[i].Pos, got)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package warning
 import (
 	"errors"
 	"io"
+	"sync"
 )
 
 // Reader is the interface that wraps the basic ReadWarning method.
@@ -31,3 +32,53 @@ func ReadAll(r Reader) ([]Warning, error) {
 
 	return result, nil
 }
+
+type filterReader struct {
+	reader     Reader
+	filterFunc func(Warning) bool
+}
+
+// NewFilterReader returns a Reader that only reads the warnings of r accepted by the provided function.
+func NewFilterReader(r Reader, filterFunc func(wrr Warning) bool) Reader {
+	return &filterReader{r, filterFunc}
+}
+
+func (r *filterReader) ReadWarning() (Warning, error) {
+	for {
+		wrr, err := r.reader.ReadWarning()
+		if err != nil {
+			return nil, err
+		}
+
+		if r.filterFunc(wrr) {
+			return wrr, nil
+		}
+	}
+}
+
+type sliceReader struct {
+	wrrs []Warning
+	mtx  sync.Mutex
+}
+
+// NewSliceReader returns a Reader that reads the given warnings in order, for example to pass
+// warnings that were already collected to the report encoders and renderers, such as [EncodeSARIF]
+// or [RenderText], which all read their warnings from a Reader.
+// The slice is not modified.
+func NewSliceReader(wrrs []Warning) Reader {
+	return &sliceReader{wrrs: wrrs}
+}
+
+func (r *sliceReader) ReadWarning() (Warning, error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	if len(r.wrrs) == 0 {
+		return nil, io.EOF
+	}
+
+	wrr := r.wrrs[0]
+	r.wrrs = r.wrrs[1:]
+
+	return wrr, nil
+}
@@ -97,3 +97,64 @@ func TestReadAll_Empty(t *testing.T) {
 		t.Fatalf("expected no warning, got %v", l)
 	}
 }
+
+func TestNewFilterReader(t *testing.T) {
+	reader := &mockReader{
+		[]mockReaderResult{
+			{warning.New("test-1"), nil},
+			{warning.New("ignore"), nil},
+			{warning.New("test-2"), nil},
+			{nil, io.EOF},
+		},
+	}
+
+	filtered := warning.NewFilterReader(reader, func(wrr warning.Warning) bool {
+		return wrr.Warn() != "ignore"
+	})
+
+	wrrs, err := warning.ReadAll(filtered)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(wrrs) != 2 || wrrs[0].Warn() != "test-1" || wrrs[1].Warn() != "test-2" {
+		t.Fatalf("expected test-1 and test-2, got %v", wrrs)
+	}
+}
+
+func TestNewFilterReader_UnexpectedError(t *testing.T) {
+	wantErr := fmt.Errorf("test-error") //nolint:err113
+
+	reader := &mockReader{
+		[]mockReaderResult{
+			{warning.New("ignore"), nil},
+			{nil, wantErr},
+		},
+	}
+
+	filtered := warning.NewFilterReader(reader, func(wrr warning.Warning) bool {
+		return wrr.Warn() != "ignore"
+	})
+
+	if _, err := filtered.ReadWarning(); !errors.Is(err, wantErr) {
+		t.Fatalf("expected %v, got %v", wantErr, err)
+	}
+}
+
+func TestNewSliceReader(t *testing.T) {
+	wrrs := []warning.Warning{warning.New("test-1"), warning.New("test-2")}
+	reader := warning.NewSliceReader(wrrs)
+
+	got, err := warning.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(got) != 2 || got[0] != wrrs[0] || got[1] != wrrs[1] {
+		t.Fatalf("expected %v, got %v", wrrs, got)
+	}
+
+	if _, err := reader.ReadWarning(); !errors.Is(err, io.EOF) {
+		t.Fatalf("expected %v, got %v", io.EOF, err)
+	}
+}
@@ -0,0 +1,237 @@
+package warning
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Definition documents a warning code. Definitions are registered once in a [Registry]
+// and used to create warnings bound to their code.
+type Definition struct {
+	// Code uniquely identifies the warning, e.g. W1042.
+	Code string
+	// Title is a short summary of the warning.
+	Title string
+	// Severity is the default severity of warnings with the code, used unless they have a severity of their own.
+	Severity Severity
+	// Explanation is a long description of the warning and how to address it.
+	Explanation string
+	// URL points to the documentation of the warning.
+	URL string
+	// Tags classify warnings created from the definition.
+	Tags []Tag
+}
+
+// New creates a new warning bound to the definition.
+func (def *Definition) New(msg string) Warning {
+	return def.Wrap(New(msg))
+}
+
+// Newf formats a new warning bound to the definition.
+// [Warning] arguments are converted to strings before formatting.
+func (def *Definition) Newf(format string, args ...any) Warning {
+	return def.Wrap(New(sprintf(format, args)))
+}
+
+// Wrap binds an existing warning to the definition.
+func (def *Definition) Wrap(wrr Warning) Warning {
+	return &definedWarning{wrr, def}
+}
+
+// String returns the code of the definition.
+func (def *Definition) String() string {
+	return def.Code
+}
+
+type definedWarning struct {
+	wrr Warning
+	def *Definition
+}
+
+func (wrr *definedWarning) Warn() string {
+	return wrr.wrr.Warn()
+}
+
+func (wrr *definedWarning) Format(state fmt.State, verb rune) {
+	formatState(state, verb, wrr)
+}
+
+func (wrr *definedWarning) String() string {
+	return stringOf(wrr.wrr)
+}
+
+func (wrr *definedWarning) MarshalJSON() ([]byte, error) {
+	return json.Marshal(wrr.wrr)
+}
+
+func (wrr *definedWarning) Code() string {
+	return wrr.def.Code
+}
+
+func (wrr *definedWarning) Tags() []Tag {
+	return wrr.def.Tags
+}
+
+func (wrr *definedWarning) Definition() *Definition {
+	return wrr.def
+}
+
+func (wrr *definedWarning) Unwrap() Warning {
+	return wrr.wrr
+}
+
+type codeWarning struct {
+	wrr  Warning
+	code string
+}
+
+// WithCode returns a warning with the given code. Unlike warnings created from a [Definition],
+// the code does not need to be registered.
+func WithCode(wrr Warning, code string) Warning {
+	return &codeWarning{wrr, code}
+}
+
+func (wrr *codeWarning) Warn() string {
+	return wrr.wrr.Warn()
+}
+
+func (wrr *codeWarning) Format(state fmt.State, verb rune) {
+	formatState(state, verb, wrr)
+}
+
+func (wrr *codeWarning) String() string {
+	return stringOf(wrr.wrr)
+}
+
+func (wrr *codeWarning) MarshalJSON() ([]byte, error) {
+	return json.Marshal(wrr.wrr)
+}
+
+func (wrr *codeWarning) Code() string {
+	return wrr.code
+}
+
+func (wrr *codeWarning) Unwrap() Warning {
+	return wrr.wrr
+}
+
+// CodeOf returns the code of the warning or any warning it wraps.
+// It returns an empty string if there is no code.
+func CodeOf(wrr Warning) string {
+	found, ok := find[interface{ Code() string }](wrr)
+	if !ok {
+		return ""
+	}
+
+	return found.Code()
+}
+
+// DefinitionOf returns the definition of the warning code. Warnings created from a [Definition]
+// return it directly, for other warnings the code is looked up in the default registry.
+func DefinitionOf(wrr Warning) (*Definition, bool) {
+	if found, ok := find[interface{ Definition() *Definition }](wrr); ok {
+		return found.Definition(), true
+	}
+
+	code := CodeOf(wrr)
+	if code == "" {
+		return nil, false
+	}
+
+	return DefaultRegistry().Lookup(code)
+}
+
+// Registry holds warning definitions indexed by their code.
+// It is safe to register and look up definitions concurrently.
+type Registry struct {
+	defs map[string]*Definition
+	mtx  sync.RWMutex
+}
+
+// NewRegistry returns a new empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{defs: make(map[string]*Definition)}
+}
+
+// Register adds the definition to the registry and returns the registered copy.
+// It returns [ErrDuplicateCode] if the code is already registered.
+func (r *Registry) Register(def Definition) (*Definition, error) {
+	if strings.TrimSpace(def.Code) == "" {
+		return nil, fmt.Errorf("%w: empty code", ErrInvalidDefinition)
+	}
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	if _, ok := r.defs[def.Code]; ok {
+		return nil, fmt.Errorf("%w: %s", ErrDuplicateCode, def.Code)
+	}
+
+	registered := &def
+	r.defs[def.Code] = registered
+
+	return registered, nil
+}
+
+// MustRegister is like [Registry.Register] but panics if the definition cannot be registered.
+// It is intended for package-level variable initialization.
+func (r *Registry) MustRegister(def Definition) *Definition {
+	registered, err := r.Register(def)
+	if err != nil {
+		panic(err)
+	}
+
+	return registered
+}
+
+// Lookup returns the definition registered for the code.
+func (r *Registry) Lookup(code string) (*Definition, bool) {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+
+	def, ok := r.defs[code]
+
+	return def, ok
+}
+
+// Definitions returns all registered definitions ordered by their code.
+func (r *Registry) Definitions() []*Definition {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+
+	defs := make([]*Definition, 0, len(r.defs))
+	for _, def := range r.defs {
+		defs = append(defs, def)
+	}
+
+	slices.SortFunc(defs, func(a, b *Definition) int {
+		return strings.Compare(a.Code, b.Code)
+	})
+
+	return defs
+}
+
+var defaultRegistry = NewRegistry() //nolint:gochecknoglobals
+
+// DefaultRegistry returns the process-wide registry used by [Register], [MustRegister] and [Lookup].
+func DefaultRegistry() *Registry {
+	return defaultRegistry
+}
+
+// Register adds the definition to the default registry.
+func Register(def Definition) (*Definition, error) {
+	return defaultRegistry.Register(def)
+}
+
+// MustRegister adds the definition to the default registry and panics if it cannot be registered.
+func MustRegister(def Definition) *Definition {
+	return defaultRegistry.MustRegister(def)
+}
+
+// Lookup returns the definition registered for the code in the default registry.
+func Lookup(code string) (*Definition, bool) {
+	return defaultRegistry.Lookup(code)
+}
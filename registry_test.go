@@ -0,0 +1,175 @@
+package warning_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"go.wamod.dev/warning"
+)
+
+// ExampleRegistry demonstrates how to declare warning codes centrally.
+func ExampleRegistry() {
+	registry := warning.NewRegistry()
+
+	unknownKey := registry.MustRegister(warning.Definition{
+		Code:     "W1042",
+		Title:    "Unknown key",
+		Severity: warning.SeverityWarning,
+		URL:      "https://example.com/warnings/W1042",
+	})
+
+	wrr := unknownKey.Newf("unknown key %q", "tiemout")
+
+	def, _ := registry.Lookup(warning.CodeOf(wrr))
+	fmt.Printf("%s: %s (%s)\n", def.Code, wrr.Warn(), def.Title)
+	fmt.Println("see", def.URL)
+
+	// Output:
+	// W1042: unknown key "tiemout" (Unknown key)
+	// see https://example.com/warnings/W1042
+}
+
+func TestRegistry_Register(t *testing.T) {
+	registry := warning.NewRegistry()
+
+	def, err := registry.Register(warning.Definition{Code: "W1", Severity: warning.SeverityError})
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	if got, ok := registry.Lookup("W1"); !ok || got != def {
+		t.Errorf("expected %v, got %v", def, got)
+	}
+
+	if _, err := registry.Register(warning.Definition{Code: "W1"}); !errors.Is(err, warning.ErrDuplicateCode) {
+		t.Errorf("expected %v, got %v", warning.ErrDuplicateCode, err)
+	}
+
+	if _, err := registry.Register(warning.Definition{}); !errors.Is(err, warning.ErrInvalidDefinition) {
+		t.Errorf("expected %v, got %v", warning.ErrInvalidDefinition, err)
+	}
+
+	if _, ok := registry.Lookup("W2"); ok {
+		t.Errorf("expected W2 not to be registered")
+	}
+}
+
+func TestRegistry_MustRegister(t *testing.T) {
+	registry := warning.NewRegistry()
+	registry.MustRegister(warning.Definition{Code: "W1"})
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("expected panic on duplicate registration")
+		}
+	}()
+
+	registry.MustRegister(warning.Definition{Code: "W1"})
+}
+
+func TestRegistry_Definitions(t *testing.T) {
+	registry := warning.NewRegistry()
+
+	for _, code := range []string{"W3", "W1", "W2"} {
+		registry.MustRegister(warning.Definition{Code: code})
+	}
+
+	defs := registry.Definitions()
+	if len(defs) != 3 {
+		t.Fatalf("expected 3 definitions, got %v", len(defs))
+	}
+
+	for i, def := range defs {
+		if want := fmt.Sprint("W", i+1); def.Code != want {
+			t.Errorf("expected %v, got %v", want, def.Code)
+		}
+	}
+}
+
+func TestDefinition_New(t *testing.T) {
+	def := warning.NewRegistry().MustRegister(warning.Definition{Code: "W1", Severity: warning.SeverityError})
+
+	args := []any{warning.New("inner")}
+	wrrs := []warning.Warning{def.New("test: inner"), def.Newf("test: %s", args...)}
+
+	for _, wrr := range wrrs {
+		if wrr.Warn() != "test: inner" {
+			t.Errorf("expected test: inner, got %v", wrr.Warn())
+		}
+
+		if got := warning.CodeOf(wrr); got != "W1" {
+			t.Errorf("expected W1, got %v", got)
+		}
+
+		if got := warning.SeverityOf(wrr); got != warning.SeverityError {
+			t.Errorf("expected %v, got %v", warning.SeverityError, got)
+		}
+
+		if got, ok := warning.DefinitionOf(wrr); !ok || got != def {
+			t.Errorf("expected %v, got %v", def, got)
+		}
+	}
+
+	if _, ok := args[0].(warning.Warning); !ok {
+		t.Errorf("expected arguments to be left untouched, got %v", args)
+	}
+
+	requireForwarded(t, wrrs[0], "test: inner")
+}
+
+func TestDefinition_Severity(t *testing.T) {
+	def := warning.NewRegistry().MustRegister(warning.Definition{Code: "W1", Severity: warning.SeverityInfo})
+
+	tests := []struct {
+		name string
+		wrr  warning.Warning
+		want warning.Severity
+	}{
+		{"default", def.New("test"), warning.SeverityInfo},
+		{"explicit outside", warning.WithSeverity(def.New("test"), warning.SeverityError), warning.SeverityError},
+		{"explicit inside", def.Wrap(warning.WithSeverity(warning.New("test"), warning.SeverityError)), warning.SeverityError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := warning.SeverityOf(tt.wrr); got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestWithCode(t *testing.T) {
+	inner := warning.New("test")
+	wrr := warning.WithCode(inner, "W-test-with-code")
+
+	if got := warning.CodeOf(wrr); got != "W-test-with-code" {
+		t.Errorf("expected W-test-with-code, got %v", got)
+	}
+
+	if got := warning.Unwrap(wrr); got != inner {
+		t.Errorf("expected %v, got %v", inner, got)
+	}
+
+	if got := warning.CodeOf(inner); got != "" {
+		t.Errorf("expected no code, got %v", got)
+	}
+
+	if _, ok := warning.DefinitionOf(warning.WithCode(inner, "W-test-unregistered")); ok {
+		t.Errorf("expected no definition")
+	}
+
+	_, err := warning.Register(warning.Definition{Code: "W-test-with-code"})
+	if err != nil && !errors.Is(err, warning.ErrDuplicateCode) {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	want, _ := warning.Lookup("W-test-with-code")
+
+	if got, ok := warning.DefinitionOf(wrr); !ok || got != want {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	requireForwarded(t, wrr, "test")
+}
@@ -0,0 +1,144 @@
+package warning
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// fingerprints returns a stable identifier for each warning, derived from its code, file, path, scope
+// and message. Line numbers are left out so that the identifiers survive unrelated edits to the file.
+// Identical warnings are told apart by the order they appear in.
+func fingerprints(wrrs []Warning) []string {
+	result := make([]string, len(wrrs))
+	seen := make(map[string]int)
+
+	for i, wrr := range wrrs {
+		var filename string
+		if rng, ok := RangeOf(wrr); ok {
+			filename = filepath.ToSlash(rng.Start.Filename)
+		}
+
+		var path string
+		if p, ok := PathOf(wrr); ok {
+			path = p.String()
+		}
+
+		hash := sha256.New()
+
+		for _, field := range []string{CodeOf(wrr), filename, path, ScopeOf(wrr), wrr.Warn()} {
+			hash.Write([]byte(field))
+			hash.Write([]byte{0})
+		}
+
+		sum := hex.EncodeToString(hash.Sum(nil)[:16])
+
+		if n := seen[sum]; n > 0 {
+			result[i] = sum + ":" + strconv.Itoa(n)
+		} else {
+			result[i] = sum
+		}
+
+		seen[sum]++
+	}
+
+	return result
+}
+
+// reportMessage returns the message of the warning followed by its notes and its hint, one per line,
+// for reports that only have a single text field per warning.
+func reportMessage(wrr Warning) string {
+	var sb strings.Builder
+
+	sb.WriteString(wrr.Warn())
+
+	for _, note := range NotesOf(wrr) {
+		sb.WriteString("\nnote: ")
+		sb.WriteString(noteText(note))
+	}
+
+	if hint := HintOf(wrr); hint != "" {
+		sb.WriteString("\nhint: ")
+		sb.WriteString(hint)
+	}
+
+	return sb.String()
+}
+
+// GroupBy selects how reports group warnings.
+type GroupBy int
+
+const (
+	// GroupByNone does not group warnings.
+	GroupByNone GroupBy = iota
+	// GroupByCode groups warnings by their code.
+	GroupByCode
+	// GroupByScope groups warnings by their scope.
+	GroupByScope
+	// GroupByFile groups warnings by the file they refer to.
+	GroupByFile
+)
+
+type warningGroup struct {
+	// key is the value warnings are grouped by, it is empty for warnings without one
+	key  string
+	wrrs []Warning
+}
+
+// groupWarnings groups the warnings, keeping groups and the warnings within them in the order they first appear.
+// With [GroupByNone], every warning is in a group of its own.
+func groupWarnings(wrrs []Warning, by GroupBy) []warningGroup {
+	var groups []warningGroup
+
+	index := make(map[string]int)
+
+	for _, wrr := range wrrs {
+		var key string
+
+		switch by {
+		case GroupByNone:
+			groups = append(groups, warningGroup{wrr.Warn(), []Warning{wrr}})
+
+			continue
+		case GroupByCode:
+			key = CodeOf(wrr)
+		case GroupByScope:
+			key = ScopeOf(wrr)
+		case GroupByFile:
+			if rng, ok := RangeOf(wrr); ok {
+				key = rng.Start.Filename
+			}
+		}
+
+		i, ok := index[key]
+		if !ok {
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, warningGroup{key: key})
+		}
+
+		groups[i].wrrs = append(groups[i].wrrs, wrr)
+	}
+
+	return groups
+}
+
+// groupName returns the key of a group, or a placeholder for the group of warnings without one.
+func groupName(key string, by GroupBy) string {
+	if key != "" {
+		return key
+	}
+
+	switch by {
+	case GroupByCode:
+		return "(no code)"
+	case GroupByScope:
+		return "(no scope)"
+	case GroupByFile:
+		return "(no file)"
+	default:
+		return "(no message)"
+	}
+}
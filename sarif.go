@@ -0,0 +1,367 @@
+package warning
+
+import (
+	"encoding/json"
+	"io"
+	"net/url"
+	"path/filepath"
+	"slices"
+)
+
+// SARIFVersion is the version of the SARIF format written by [EncodeSARIF].
+const SARIFVersion = "2.1.0"
+
+const sarifSchema = "https://json.schemastore.org/sarif-2.1.0.json"
+
+// SARIFOptions describes the tool reported in a SARIF log.
+type SARIFOptions struct {
+	// ToolName is the name of the tool that produced the warnings. It defaults to "warning".
+	ToolName string
+	// ToolVersion is the version of the tool.
+	ToolVersion string
+	// InformationURI points to the documentation of the tool.
+	InformationURI string
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri,omitempty"`
+	Rules          []sarifRule `json:"rules,omitempty"`
+}
+
+type sarifRule struct {
+	ID                   string              `json:"id"`
+	ShortDescription     *sarifMessage       `json:"shortDescription,omitempty"`
+	FullDescription      *sarifMessage       `json:"fullDescription,omitempty"`
+	HelpURI              string              `json:"helpUri,omitempty"`
+	DefaultConfiguration *sarifConfiguration `json:"defaultConfiguration,omitempty"`
+	Properties           *sarifProperties    `json:"properties,omitempty"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifProperties struct {
+	Tags []Tag `json:"tags,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID              string            `json:"ruleId,omitempty"`
+	RuleIndex           *int              `json:"ruleIndex,omitempty"`
+	Level               string            `json:"level"`
+	Message             sarifMessage      `json:"message"`
+	Locations           []sarifLocation   `json:"locations,omitempty"`
+	RelatedLocations    []sarifLocation   `json:"relatedLocations,omitempty"`
+	PartialFingerprints map[string]string `json:"partialFingerprints"`
+	Fixes               []sarifFix        `json:"fixes,omitempty"`
+	Properties          *sarifProperties  `json:"properties,omitempty"`
+}
+
+type sarifLocation struct {
+	ID               *int                   `json:"id,omitempty"`
+	Message          *sarifMessage          `json:"message,omitempty"`
+	PhysicalLocation *sarifPhysicalLocation `json:"physicalLocation,omitempty"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int  `json:"startLine,omitempty"`
+	StartColumn int  `json:"startColumn,omitempty"`
+	EndLine     int  `json:"endLine,omitempty"`
+	EndColumn   int  `json:"endColumn,omitempty"`
+	ByteOffset  *int `json:"byteOffset,omitempty"`
+	ByteLength  *int `json:"byteLength,omitempty"`
+}
+
+type sarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+}
+
+type sarifFix struct {
+	Description     *sarifMessage         `json:"description,omitempty"`
+	ArtifactChanges []sarifArtifactChange `json:"artifactChanges"`
+}
+
+type sarifArtifactChange struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Replacements     []sarifReplacement    `json:"replacements"`
+}
+
+type sarifReplacement struct {
+	DeletedRegion   sarifRegion   `json:"deletedRegion"`
+	InsertedContent *sarifMessage `json:"insertedContent,omitempty"`
+}
+
+// EncodeSARIF writes the warnings read from r to w as a SARIF 2.1.0 log with a single run.
+//
+// Each warning code becomes a rule, described by its [Definition] when one is registered.
+// Severities map to the SARIF levels error, warning and note, source ranges to physical locations,
+// field paths to logical locations, notes to related locations and suggested fixes to fixes.
+// The hint is appended to the message text. Each result carries a fingerprint that does not depend on line numbers.
+//
+// Positions are written as is: lines and columns are 1-based and columns are counted in bytes.
+// Warnings without a code are written without a rule.
+func EncodeSARIF(w io.Writer, r Reader, opts SARIFOptions) error {
+	wrrs, err := ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	driver := sarifDriver{
+		Name:           opts.ToolName,
+		Version:        opts.ToolVersion,
+		InformationURI: opts.InformationURI,
+	}
+
+	if driver.Name == "" {
+		driver.Name = "warning"
+	}
+
+	rules := make(map[string]int)
+	results := make([]sarifResult, 0, len(wrrs))
+	prints := fingerprints(wrrs)
+
+	for i, wrr := range wrrs {
+		msg := wrr.Warn()
+		if hint := HintOf(wrr); hint != "" {
+			msg += "\nhint: " + hint
+		}
+
+		result := sarifResult{
+			Level:               sarifLevel(SeverityOf(wrr)),
+			Message:             sarifMessage{msg},
+			PartialFingerprints: map[string]string{"warningHash/v1": prints[i]},
+		}
+
+		if code := CodeOf(wrr); code != "" {
+			index, ok := rules[code]
+			if !ok {
+				index = len(driver.Rules)
+				rules[code] = index
+				driver.Rules = append(driver.Rules, sarifRuleOf(wrr, code))
+			}
+
+			result.RuleID = code
+			result.RuleIndex = &index
+		}
+
+		var loc sarifLocation
+
+		if rng, ok := RangeOf(wrr); ok {
+			loc.PhysicalLocation = sarifPhysical(rng)
+		}
+
+		if path, ok := PathOf(wrr); ok && len(path) > 0 {
+			loc.LogicalLocations = []sarifLogicalLocation{{path.String()}}
+		}
+
+		if loc.PhysicalLocation != nil || loc.LogicalLocations != nil {
+			result.Locations = []sarifLocation{loc}
+		}
+
+		for j, note := range NotesOf(wrr) {
+			id := j + 1
+			result.RelatedLocations = append(result.RelatedLocations, sarifLocation{
+				ID:               &id,
+				Message:          &sarifMessage{note.Message},
+				PhysicalLocation: sarifPhysical(note.Range),
+			})
+		}
+
+		for _, fix := range FixesOf(wrr) {
+			result.Fixes = append(result.Fixes, sarifFixOf(fix))
+		}
+
+		if tags := TagsOf(wrr); len(tags) > 0 {
+			result.Properties = &sarifProperties{tags}
+		}
+
+		results = append(results, result)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(sarifLog{
+		Schema:  sarifSchema,
+		Version: SARIFVersion,
+		Runs:    []sarifRun{{Tool: sarifTool{driver}, Results: results}},
+	})
+}
+
+func sarifLevel(severity Severity) string {
+	switch {
+	case severity >= SeverityError:
+		return "error"
+	case severity == SeverityWarning:
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+func sarifRuleOf(wrr Warning, code string) sarifRule {
+	rule := sarifRule{ID: code}
+
+	def, ok := DefinitionOf(wrr)
+	if !ok || def.Code != code {
+		return rule
+	}
+
+	if def.Title != "" {
+		rule.ShortDescription = &sarifMessage{def.Title}
+	}
+
+	if def.Explanation != "" {
+		rule.FullDescription = &sarifMessage{def.Explanation}
+	}
+
+	rule.HelpURI = def.URL
+	rule.DefaultConfiguration = &sarifConfiguration{sarifLevel(def.Severity)}
+
+	if len(def.Tags) > 0 {
+		rule.Properties = &sarifProperties{slices.Clone(def.Tags)}
+	}
+
+	return rule
+}
+
+// sarifPhysical returns the physical location of the range, or nil if the range has no file name.
+func sarifPhysical(rng Range) *sarifPhysicalLocation {
+	if rng.Start.Filename == "" {
+		return nil
+	}
+
+	loc := &sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{sarifURI(rng.Start.Filename)}}
+
+	region := sarifRegionOf(rng)
+	if region != (sarifRegion{}) {
+		loc.Region = &region
+	}
+
+	return loc
+}
+
+func sarifRegionOf(rng Range) sarifRegion {
+	var region sarifRegion
+
+	switch {
+	case rng.Start.Line > 0:
+		region.StartLine = rng.Start.Line
+		region.StartColumn = rng.Start.Column
+
+		if rng.End.Line > 0 {
+			region.EndLine = rng.End.Line
+			region.EndColumn = rng.End.Column
+		}
+	default:
+		// without a line, the position is a byte offset, which is also valid at the start of the file
+		offset := rng.Start.Offset
+		region.ByteOffset = &offset
+
+		if rng.End.Offset > offset {
+			length := rng.End.Offset - offset
+			region.ByteLength = &length
+		}
+	}
+
+	return region
+}
+
+func sarifFixOf(fix Fix) sarifFix {
+	var result sarifFix
+
+	if fix.Message != "" {
+		result.Description = &sarifMessage{fix.Message}
+	}
+
+	for _, edit := range fix.Edits {
+		uri := sarifURI(edit.Range.Start.Filename)
+
+		index := slices.IndexFunc(result.ArtifactChanges, func(change sarifArtifactChange) bool {
+			return change.ArtifactLocation.URI == uri
+		})
+
+		if index < 0 {
+			index = len(result.ArtifactChanges)
+			result.ArtifactChanges = append(result.ArtifactChanges, sarifArtifactChange{
+				ArtifactLocation: sarifArtifactLocation{uri},
+				Replacements:     []sarifReplacement{},
+			})
+		}
+
+		region := sarifRegionOf(edit.Range)
+
+		// an edit without an end is an insertion, which SARIF describes as an empty region
+		if region.StartLine > 0 && region.EndLine == 0 {
+			region.EndLine, region.EndColumn = region.StartLine, region.StartColumn
+		}
+
+		if region.ByteOffset != nil && region.ByteLength == nil {
+			length := 0
+			region.ByteLength = &length
+		}
+
+		replacement := sarifReplacement{DeletedRegion: region}
+		if edit.NewText != "" {
+			replacement.InsertedContent = &sarifMessage{edit.NewText}
+		}
+
+		change := &result.ArtifactChanges[index]
+		change.Replacements = append(change.Replacements, replacement)
+	}
+
+	return result
+}
+
+// sarifURI returns the file name as a URI reference: relative names use forward slashes,
+// absolute names become file URIs.
+func sarifURI(filename string) string {
+	filename = filepath.ToSlash(filename)
+
+	if filepath.IsAbs(filepath.FromSlash(filename)) {
+		return fileURL(filename)
+	}
+
+	return (&url.URL{Path: filename}).String()
+}
+
+// fileURL returns the file URI of the absolute file name, which uses forward slashes.
+func fileURL(filename string) string {
+	if filename != "" && filename[0] != '/' {
+		// Windows drive letter, e.g. C:/src/main.go
+		filename = "/" + filename
+	}
+
+	return (&url.URL{Scheme: "file", Path: filename}).String()
+}
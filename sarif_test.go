@@ -0,0 +1,229 @@
+package warning_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"go/token"
+	"strings"
+	"testing"
+
+	"go.wamod.dev/warning"
+)
+
+var sarifDefinition = warning.MustRegister(warning.Definition{ //nolint:gochecknoglobals
+	Code:        "SARIF1",
+	Title:       "Unknown key",
+	Explanation: "The key is not part of the configuration schema.",
+	Severity:    warning.SeverityError,
+	URL:         "https://example.com/SARIF1",
+	Tags:        []warning.Tag{warning.TagUnnecessary},
+})
+
+func reportWarnings() []warning.Warning {
+	start := token.Position{Filename: "config/app.toml", Line: 3, Column: 1}
+	end := token.Position{Filename: "config/app.toml", Line: 3, Column: 8}
+
+	return []warning.Warning{
+		warning.WithFixes(
+			warning.WithNotes(
+				warning.WithRange(sarifDefinition.New(`unknown key "tiemout"`), warning.Range{Start: start, End: end}),
+				warning.Note{Message: "defined here", Range: warning.Range{Start: start}},
+			),
+			warning.Fix{Message: "rename to timeout", Edits: []warning.Edit{
+				{Range: warning.Range{Start: start, End: end}, NewText: "timeout"},
+				{Range: warning.Range{Start: end}, NewText: " "},
+			}},
+		),
+		warning.WithPosition(warning.WithCode(warning.New("deprecated option"), "W2"), token.Position{Filename: "/src/main.go", Line: 10, Column: 2}),
+		warning.WithSeverity(warning.WithPath(warning.New("value out of range"), warning.Path{}.Field("servers").Index(0)), warning.SeverityInfo),
+		sarifDefinition.New(`unknown key "tiemout"`),
+		sarifDefinition.New(`unknown key "tiemout"`),
+	}
+}
+
+func TestEncodeSARIF(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := warning.EncodeSARIF(&buf, warning.NewSliceReader(reportWarnings()), warning.SARIFOptions{
+		ToolName:    "confcheck",
+		ToolVersion: "1.2.3",
+	})
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	var log map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("expected valid JSON, got %v", err)
+	}
+
+	// required properties of the SARIF 2.1.0 schema
+	requireFields(t, log, "log", "version", "runs")
+
+	if log["version"] != "2.1.0" {
+		t.Errorf("expected version 2.1.0, got %v", log["version"])
+	}
+
+	run := log["runs"].([]any)[0].(map[string]any)
+	requireFields(t, run, "run", "tool")
+
+	driver := run["tool"].(map[string]any)["driver"].(map[string]any)
+	requireFields(t, driver, "driver", "name")
+
+	rules := driver["rules"].([]any)
+	if len(rules) != 2 {
+		t.Fatalf("expected 2 rules, got %v", rules)
+	}
+
+	for _, rule := range rules {
+		requireFields(t, rule.(map[string]any), "rule", "id")
+	}
+
+	if got := rules[0].(map[string]any)["helpUri"]; got != "https://example.com/SARIF1" {
+		t.Errorf("expected help URI, got %v", got)
+	}
+
+	results := run["results"].([]any)
+	if len(results) != 5 {
+		t.Fatalf("expected 5 results, got %d", len(results))
+	}
+
+	fingerprints := make(map[any]bool)
+
+	for _, result := range results {
+		result := result.(map[string]any)
+		requireFields(t, result, "result", "message", "partialFingerprints")
+		requireFields(t, result["message"].(map[string]any), "message", "text")
+
+		fingerprint := result["partialFingerprints"].(map[string]any)["warningHash/v1"]
+		if fingerprints[fingerprint] {
+			t.Errorf("expected unique fingerprints, got %v twice", fingerprint)
+		}
+
+		fingerprints[fingerprint] = true
+
+		for _, fix := range asSlice(result["fixes"]) {
+			fix := fix.(map[string]any)
+			requireFields(t, fix, "fix", "artifactChanges")
+
+			for _, change := range asSlice(fix["artifactChanges"]) {
+				change := change.(map[string]any)
+				requireFields(t, change, "artifactChange", "artifactLocation", "replacements")
+
+				for _, replacement := range asSlice(change["replacements"]) {
+					requireFields(t, replacement.(map[string]any), "replacement", "deletedRegion")
+				}
+			}
+		}
+	}
+
+	first := results[0].(map[string]any)
+	if first["level"] != "error" || first["ruleId"] != "SARIF1" || first["ruleIndex"] != 0.0 {
+		t.Errorf("unexpected first result %v", first)
+	}
+
+	for _, want := range []string{
+		`"uri": "config/app.toml"`,
+		`"uri": "file:///src/main.go"`,
+		`"fullyQualifiedName": "servers[0]"`,
+		`"level": "note"`,
+		`"startLine": 3`,
+		`"endColumn": 8`,
+		`"text": "defined here"`,
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("expected output to contain %s", want)
+		}
+	}
+}
+
+func TestEncodeSARIF_Hint(t *testing.T) {
+	var buf bytes.Buffer
+
+	wrrs := []warning.Warning{warning.WithHint(warning.New(`unknown key "tiemout"`), `did you mean "timeout"?`)}
+
+	if err := warning.EncodeSARIF(&buf, warning.NewSliceReader(wrrs), warning.SARIFOptions{}); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	if want := `"text": "unknown key \"tiemout\"\nhint: did you mean \"timeout\"?"`; !strings.Contains(buf.String(), want) {
+		t.Errorf("expected output to contain %s, got %s", want, buf.String())
+	}
+}
+
+func TestEncodeSARIF_Offset(t *testing.T) {
+	var buf bytes.Buffer
+
+	// an insertion at the start of a file, located by byte offsets only
+	start := token.Position{Filename: "main.go"}
+	wrrs := []warning.Warning{warning.WithFixes(
+		warning.WithRange(warning.New("missing header"), warning.Range{Start: start, End: token.Position{Filename: "main.go", Offset: 4}}),
+		warning.Fix{Message: "add header", Edits: []warning.Edit{{Range: warning.Range{Start: start}, NewText: "// header\n"}}},
+	)}
+
+	if err := warning.EncodeSARIF(&buf, warning.NewSliceReader(wrrs), warning.SARIFOptions{}); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	var log struct {
+		Runs []struct {
+			Results []struct {
+				Locations []struct {
+					PhysicalLocation struct {
+						Region map[string]any `json:"region"`
+					} `json:"physicalLocation"`
+				} `json:"locations"`
+				Fixes []struct {
+					ArtifactChanges []struct {
+						Replacements []struct {
+							DeletedRegion map[string]any `json:"deletedRegion"`
+						} `json:"replacements"`
+					} `json:"artifactChanges"`
+				} `json:"fixes"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("expected valid JSON, got %v", err)
+	}
+
+	result := log.Runs[0].Results[0]
+
+	region := result.Locations[0].PhysicalLocation.Region
+	if region["byteOffset"] != 0.0 || region["byteLength"] != 4.0 {
+		t.Errorf("expected byteOffset 0 and byteLength 4, got %v", region)
+	}
+
+	deleted := result.Fixes[0].ArtifactChanges[0].Replacements[0].DeletedRegion
+	if deleted["byteOffset"] != 0.0 || deleted["byteLength"] != 0.0 {
+		t.Errorf("expected byteOffset 0 and byteLength 0, got %v", deleted)
+	}
+}
+
+func TestEncodeSARIF_ReadError(t *testing.T) {
+	collector := warning.NewCollector()
+	collector.Close()
+
+	err := warning.EncodeSARIF(&bytes.Buffer{}, collector, warning.SARIFOptions{})
+	if !errors.Is(err, warning.ErrClosed) {
+		t.Errorf("expected %v, got %v", warning.ErrClosed, err)
+	}
+}
+
+func requireFields(t *testing.T, obj map[string]any, name string, fields ...string) {
+	t.Helper()
+
+	for _, field := range fields {
+		if _, ok := obj[field]; !ok {
+			t.Errorf("expected %s to have required property %q, got %v", name, field, obj)
+		}
+	}
+}
+
+func asSlice(v any) []any {
+	s, _ := v.([]any)
+
+	return s
+}
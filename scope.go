@@ -0,0 +1,96 @@
+package warning
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+type scopeKey struct{}
+
+// ScopeFrom returns the scope accumulated in the context by [WithScope].
+func ScopeFrom(ctx context.Context) string {
+	scope, _ := ctx.Value(scopeKey{}).(string)
+
+	return scope
+}
+
+// WithScope returns a new context with the name appended to its scope. Nested scopes
+// are joined with a dot, e.g. db.migrations. Scopes usually name the component emitting warnings.
+// Warnings written to the returned context that do not have a scope yet are attributed to the accumulated scope.
+func WithScope(ctx context.Context, name string) context.Context {
+	scope := name
+	if parent := ScopeFrom(ctx); parent != "" {
+		scope = parent + "." + name
+	}
+
+	ctx = context.WithValue(ctx, scopeKey{}, scope)
+
+	writer := getWriter(ctx)
+	if writer == nil {
+		return ctx
+	}
+
+	if found, ok := writer.(*scopeWriter); ok {
+		writer = found.next
+	}
+
+	return setWriter(ctx, &scopeWriter{writer, scope})
+}
+
+type scopeWriter struct {
+	next  Writer
+	scope string
+}
+
+func (writer *scopeWriter) WriteWarning(wrr Warning) error {
+	return writer.WriteWarningContext(context.Background(), wrr)
+}
+
+func (writer *scopeWriter) WriteWarningContext(ctx context.Context, wrr Warning) error {
+	if ScopeOf(wrr) == "" {
+		wrr = &scopeWarning{wrr, writer.scope}
+	}
+
+	return writeWarning(ctx, writer.next, wrr)
+}
+
+type scopeWarning struct {
+	wrr   Warning
+	scope string
+}
+
+func (wrr *scopeWarning) Warn() string {
+	return wrr.wrr.Warn()
+}
+
+func (wrr *scopeWarning) Format(state fmt.State, verb rune) {
+	formatState(state, verb, wrr)
+}
+
+func (wrr *scopeWarning) String() string {
+	return stringOf(wrr.wrr)
+}
+
+func (wrr *scopeWarning) MarshalJSON() ([]byte, error) {
+	return json.Marshal(wrr.wrr)
+}
+
+func (wrr *scopeWarning) Scope() string {
+	return wrr.scope
+}
+
+func (wrr *scopeWarning) Unwrap() Warning {
+	return wrr.wrr
+}
+
+// ScopeOf returns the scope the warning was written in.
+// It returns an empty string if the warning was not written in a scope.
+func ScopeOf(wrr Warning) string {
+	found, ok := find[interface{ Scope() string }](wrr)
+	if !ok {
+		return ""
+	}
+
+	return found.Scope()
+}
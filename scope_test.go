@@ -0,0 +1,57 @@
+package warning_test
+
+import (
+	"context"
+	"testing"
+
+	"go.wamod.dev/warning"
+)
+
+func TestWithScope(t *testing.T) {
+	writer := &mockWriter{}
+
+	ctx := warning.Attach(context.Background(), writer)
+	ctx = warning.WithScope(ctx, "db")
+
+	warning.Warn(ctx, warning.New("test-1"))
+	warning.Warn(warning.WithScope(ctx, "migrations"), warning.New("test-2"))
+
+	want := []string{"db", "db.migrations"}
+
+	if len(writer.buf) != len(want) {
+		t.Fatalf("expected %v warnings, got %v", len(want), len(writer.buf))
+	}
+
+	for i, wrr := range writer.buf {
+		if got := warning.ScopeOf(wrr); got != want[i] {
+			t.Errorf("expected %v, got %v", want[i], got)
+		}
+
+		if got := warning.Unwrap(wrr); got == nil || got.Warn() != wrr.Warn() {
+			t.Errorf("expected wrapped warning, got %v", got)
+		}
+	}
+
+	if got := warning.ScopeFrom(ctx); got != "db" {
+		t.Errorf("expected db, got %v", got)
+	}
+
+	requireForwarded(t, writer.buf[0], "test-1")
+}
+
+func TestWithScopeNoWriter(t *testing.T) {
+	ctx := warning.WithScope(context.Background(), "db")
+
+	if got := warning.ScopeFrom(ctx); got != "db" {
+		t.Errorf("expected db, got %v", got)
+	}
+
+	if got := warning.ScopeOf(warning.New("test")); got != "" {
+		t.Errorf("expected no scope, got %v", got)
+	}
+
+	err := warning.Warn(ctx, warning.New("test"))
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+}
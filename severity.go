@@ -0,0 +1,121 @@
+package warning
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Severity describes how important a warning is. Severities are ordered,
+// a greater severity is more important. The zero value is [SeverityWarning].
+type Severity int
+
+const (
+	// SeverityHint marks suggestions that are not a problem on their own.
+	SeverityHint Severity = iota - 2
+	// SeverityInfo marks informational diagnostics.
+	SeverityInfo
+	// SeverityWarning marks regular warnings. It is the severity of warnings that do not specify one.
+	SeverityWarning
+	// SeverityError marks diagnostics that should be treated as errors.
+	SeverityError
+)
+
+// String returns the lower-case name of the severity.
+func (s Severity) String() string {
+	switch s {
+	case SeverityHint:
+		return "hint"
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	default:
+		return "severity(" + strconv.Itoa(int(s)) + ")"
+	}
+}
+
+// MarshalText implements [encoding.TextMarshaler].
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements [encoding.TextUnmarshaler].
+func (s *Severity) UnmarshalText(text []byte) error {
+	parsed, err := ParseSeverity(string(text))
+	if err != nil {
+		return err
+	}
+
+	*s = parsed
+
+	return nil
+}
+
+// ParseSeverity parses the name of a severity. It is case-insensitive and accepts
+// "warn" and "information" as aliases.
+func ParseSeverity(s string) (Severity, error) {
+	switch strings.ToLower(s) {
+	case "hint":
+		return SeverityHint, nil
+	case "info", "information":
+		return SeverityInfo, nil
+	case "warning", "warn":
+		return SeverityWarning, nil
+	case "error":
+		return SeverityError, nil
+	default:
+		return 0, fmt.Errorf("%w: %q", ErrUnknownSeverity, s)
+	}
+}
+
+type severityWarning struct {
+	wrr      Warning
+	severity Severity
+}
+
+// WithSeverity returns a warning with the given severity.
+func WithSeverity(wrr Warning, severity Severity) Warning {
+	return &severityWarning{wrr, severity}
+}
+
+func (wrr *severityWarning) Warn() string {
+	return wrr.wrr.Warn()
+}
+
+func (wrr *severityWarning) Format(state fmt.State, verb rune) {
+	formatState(state, verb, wrr)
+}
+
+func (wrr *severityWarning) String() string {
+	return stringOf(wrr.wrr)
+}
+
+func (wrr *severityWarning) MarshalJSON() ([]byte, error) {
+	return json.Marshal(wrr.wrr)
+}
+
+func (wrr *severityWarning) Severity() Severity {
+	return wrr.severity
+}
+
+func (wrr *severityWarning) Unwrap() Warning {
+	return wrr.wrr
+}
+
+// SeverityOf returns the severity of the warning or any warning it wraps. Warnings without a severity
+// are reported with the default severity of their definition, see [DefinitionOf], or as [SeverityWarning].
+func SeverityOf(wrr Warning) Severity {
+	if found, ok := find[interface{ Severity() Severity }](wrr); ok {
+		return found.Severity()
+	}
+
+	if def, ok := DefinitionOf(wrr); ok {
+		return def.Severity
+	}
+
+	return SeverityWarning
+}
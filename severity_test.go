@@ -0,0 +1,95 @@
+package warning_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"go.wamod.dev/warning"
+)
+
+func TestSeverity_String(t *testing.T) {
+	tests := map[warning.Severity]string{
+		warning.SeverityHint:    "hint",
+		warning.SeverityInfo:    "info",
+		warning.SeverityWarning: "warning",
+		warning.SeverityError:   "error",
+		warning.Severity(10):    "severity(10)",
+	}
+
+	for severity, want := range tests {
+		if got := severity.String(); got != want {
+			t.Errorf("expected %v, got %v", want, got)
+		}
+	}
+}
+
+func TestParseSeverity(t *testing.T) {
+	tests := map[string]warning.Severity{
+		"hint":    warning.SeverityHint,
+		"INFO":    warning.SeverityInfo,
+		"warn":    warning.SeverityWarning,
+		"Warning": warning.SeverityWarning,
+		"error":   warning.SeverityError,
+	}
+
+	for text, want := range tests {
+		got, err := warning.ParseSeverity(text)
+		if err != nil {
+			t.Fatalf("expected nil error, got %v", err)
+		}
+
+		if got != want {
+			t.Errorf("%s: expected %v, got %v", text, want, got)
+		}
+	}
+
+	if _, err := warning.ParseSeverity("fatal"); !errors.Is(err, warning.ErrUnknownSeverity) {
+		t.Errorf("expected %v, got %v", warning.ErrUnknownSeverity, err)
+	}
+}
+
+func TestSeverity_JSON(t *testing.T) {
+	data, err := json.Marshal([]warning.Severity{warning.SeverityInfo, warning.SeverityError})
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	if string(data) != `["info","error"]` {
+		t.Errorf(`expected ["info","error"], got %s`, data)
+	}
+
+	var got []warning.Severity
+
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	if len(got) != 2 || got[0] != warning.SeverityInfo || got[1] != warning.SeverityError {
+		t.Errorf("expected [info error], got %v", got)
+	}
+}
+
+func TestWithSeverity(t *testing.T) {
+	inner := warning.New("test")
+
+	if got := warning.SeverityOf(inner); got != warning.SeverityWarning {
+		t.Errorf("expected %v, got %v", warning.SeverityWarning, got)
+	}
+
+	wrr := warning.WithSeverity(inner, warning.SeverityError)
+
+	if wrr.Warn() != "test" {
+		t.Errorf("expected test, got %v", wrr.Warn())
+	}
+
+	if got := warning.Unwrap(wrr); got != inner {
+		t.Errorf("expected %v, got %v", inner, got)
+	}
+
+	if got := warning.SeverityOf(warning.WithSeverity(wrr, warning.SeverityInfo)); got != warning.SeverityInfo {
+		t.Errorf("expected outermost severity %v, got %v", warning.SeverityInfo, got)
+	}
+
+	requireForwarded(t, wrr, "test")
+}
@@ -0,0 +1,185 @@
+package warning
+
+import (
+	"context"
+	"io"
+	"math/rand/v2"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// ShardedCollectorOptions configures a [ShardedCollector].
+type ShardedCollectorOptions struct {
+	// Shards is the number of independent buffers warnings are spread across.
+	// If zero or negative, it defaults to four times [runtime.GOMAXPROCS].
+	Shards int
+	// Ordered makes the collector read warnings in the order they were written,
+	// at the cost of a shared sequence counter updated on every write.
+	Ordered bool
+}
+
+// ShardedCollector captures warnings like [Collector], but spreads concurrent writes across several
+// independently locked buffers, so that many goroutines can write warnings without contending on a single lock.
+// It implements the [Reader], [Writer] and [io.Closer] interfaces.
+//
+// Warnings are read in an unspecified order unless [ShardedCollectorOptions.Ordered] is set, in which case
+// they are read in the order they were written. Only a read that runs concurrently with writes may return
+// a warning before one written earlier by another goroutine, once all writes returned the order is exact.
+//
+// Warnings are stamped like they are by a [Collector].
+//
+// Read operations are non-blocking and return [io.EOF] when there are no more warnings in the buffer.
+// It is safe to read and write warnings concurrently.
+type ShardedCollector struct {
+	shards  []collectorShard
+	ordered bool
+	seq     atomic.Uint64
+	closed  atomic.Bool
+
+	// readMtx serializes readers, writers only lock the shard they write to
+	readMtx sync.Mutex
+	cursor  int
+}
+
+type collectorShard struct {
+	mtx sync.Mutex
+	buf []shardEntry
+
+	// keep shards on separate cache lines so writers to different shards do not slow each other down
+	_ [32]byte
+}
+
+type shardEntry struct {
+	seq uint64
+	wrr Warning
+}
+
+// NewShardedCollector returns a new ShardedCollector.
+func NewShardedCollector(opts ShardedCollectorOptions) *ShardedCollector {
+	shards := opts.Shards
+	if shards <= 0 {
+		shards = 4 * runtime.GOMAXPROCS(0)
+	}
+
+	return &ShardedCollector{
+		shards:  make([]collectorShard, shards),
+		ordered: opts.Ordered,
+	}
+}
+
+// Close closes the collector.
+func (c *ShardedCollector) Close() error {
+	if c.closed.Swap(true) {
+		return ErrClosed
+	}
+
+	for i := range c.shards {
+		shard := &c.shards[i]
+
+		shard.mtx.Lock()
+		shard.buf = nil
+		shard.mtx.Unlock()
+	}
+
+	return nil
+}
+
+// WriteWarning writes a warning to the collector.
+func (c *ShardedCollector) WriteWarning(wrr Warning) error {
+	return c.WriteWarningContext(context.Background(), wrr)
+}
+
+// WriteWarningContext writes a warning to the collector, stamped with the time of the clock set by [WithClock].
+func (c *ShardedCollector) WriteWarningContext(ctx context.Context, wrr Warning) error {
+	shard := &c.shards[rand.IntN(len(c.shards))] //nolint:gosec
+
+	shard.mtx.Lock()
+	defer shard.mtx.Unlock()
+
+	if c.closed.Load() {
+		return ErrClosed
+	}
+
+	entry := shardEntry{wrr: stamp(wrr, clockFrom(ctx))}
+
+	// number the warning while holding the shard lock, so that each shard stays sorted
+	if c.ordered {
+		entry.seq = c.seq.Add(1)
+	}
+
+	shard.buf = append(shard.buf, entry)
+
+	return nil
+}
+
+// ReadWarning reads a warning from the collector.
+func (c *ShardedCollector) ReadWarning() (Warning, error) {
+	c.readMtx.Lock()
+	defer c.readMtx.Unlock()
+
+	if c.closed.Load() {
+		return nil, ErrClosed
+	}
+
+	if c.ordered {
+		return c.readOrdered()
+	}
+
+	// continue from the shard after the last one read, so that no shard is starved
+	for i := range c.shards {
+		idx := (c.cursor + i) % len(c.shards)
+
+		if wrr, ok := c.shards[idx].pop(); ok {
+			c.cursor = idx + 1
+
+			return wrr, nil
+		}
+	}
+
+	return nil, io.EOF
+}
+
+// readOrdered reads the warning with the lowest sequence number among the heads of all shards.
+// Each shard is sorted by sequence number, so once all writes returned, the head found is the warning written first.
+func (c *ShardedCollector) readOrdered() (Warning, error) {
+	found := -1
+
+	var lowest uint64
+
+	for i := range c.shards {
+		shard := &c.shards[i]
+
+		shard.mtx.Lock()
+		if len(shard.buf) > 0 && (found < 0 || shard.buf[0].seq < lowest) {
+			found, lowest = i, shard.buf[0].seq
+		}
+		shard.mtx.Unlock()
+	}
+
+	if found < 0 {
+		return nil, io.EOF
+	}
+
+	// the head can only be removed by Close, which clears all shards
+	wrr, ok := c.shards[found].pop()
+	if !ok {
+		return nil, ErrClosed
+	}
+
+	return wrr, nil
+}
+
+func (shard *collectorShard) pop() (Warning, bool) {
+	shard.mtx.Lock()
+	defer shard.mtx.Unlock()
+
+	if len(shard.buf) == 0 {
+		return nil, false
+	}
+
+	wrr := shard.buf[0].wrr
+	shard.buf = shard.buf[1:]
+
+	return wrr, true
+}
@@ -0,0 +1,186 @@
+package warning_test
+
+import (
+	"errors"
+	"io"
+	"runtime"
+	"sync"
+	"testing"
+
+	"go.wamod.dev/warning"
+)
+
+func TestShardedCollector(t *testing.T) {
+	collector := warning.NewShardedCollector(warning.ShardedCollectorOptions{Shards: 4})
+
+	var wg sync.WaitGroup
+
+	for range 8 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for range 100 {
+				if err := collector.WriteWarning(warning.New("test")); err != nil {
+					t.Errorf("expected nil error, got %v", err)
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	wrrs, err := warning.ReadAll(collector)
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	if len(wrrs) != 800 {
+		t.Errorf("expected 800 warnings, got %v", len(wrrs))
+	}
+
+	if _, err := collector.ReadWarning(); !errors.Is(err, io.EOF) {
+		t.Errorf("expected %v, got %v", io.EOF, err)
+	}
+
+	if err := collector.Close(); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	if err := collector.Close(); !errors.Is(err, warning.ErrClosed) {
+		t.Errorf("expected %v, got %v", warning.ErrClosed, err)
+	}
+
+	if err := collector.WriteWarning(warning.New("test")); !errors.Is(err, warning.ErrClosed) {
+		t.Errorf("expected %v, got %v", warning.ErrClosed, err)
+	}
+
+	if _, err := collector.ReadWarning(); !errors.Is(err, warning.ErrClosed) {
+		t.Errorf("expected %v, got %v", warning.ErrClosed, err)
+	}
+}
+
+func TestShardedCollector_Ordered(t *testing.T) {
+	collector := warning.NewShardedCollector(warning.ShardedCollectorOptions{Ordered: true, Shards: 8})
+	defer collector.Close()
+
+	want := []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j"}
+
+	for _, msg := range want {
+		if err := collector.WriteWarning(warning.New(msg)); err != nil {
+			t.Fatalf("expected nil error, got %v", err)
+		}
+	}
+
+	wrrs, err := warning.ReadAll(collector)
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	if len(wrrs) != len(want) {
+		t.Fatalf("expected %d warnings, got %v", len(want), wrrs)
+	}
+
+	for i, wrr := range wrrs {
+		if wrr.Warn() != want[i] {
+			t.Errorf("expected %v at %d, got %v", want[i], i, wrr.Warn())
+		}
+	}
+}
+
+func TestShardedCollector_ReadClose(t *testing.T) {
+	for range 100 {
+		collector := warning.NewShardedCollector(warning.ShardedCollectorOptions{Ordered: true})
+
+		for range 100 {
+			_ = collector.WriteWarning(warning.New("test"))
+		}
+
+		go collector.Close()
+
+		for {
+			wrr, err := collector.ReadWarning()
+			if err != nil {
+				if !errors.Is(err, warning.ErrClosed) && !errors.Is(err, io.EOF) {
+					t.Fatalf("expected %v or %v, got %v", warning.ErrClosed, io.EOF, err)
+				}
+
+				break
+			}
+
+			if wrr == nil {
+				t.Fatal("expected a warning or an error, got neither")
+			}
+		}
+	}
+}
+
+func BenchmarkCollectorParallel(b *testing.B) {
+	type readWriteCloser interface {
+		warning.Reader
+		warning.Writer
+		io.Closer
+	}
+
+	collectors := []struct {
+		name         string
+		newCollector func() readWriteCloser
+	}{
+		{"Collector", func() readWriteCloser {
+			return warning.NewCollector()
+		}},
+		{"ShardedCollector", func() readWriteCloser {
+			return warning.NewShardedCollector(warning.ShardedCollectorOptions{})
+		}},
+		{"ShardedCollector/ordered", func() readWriteCloser {
+			return warning.NewShardedCollector(warning.ShardedCollectorOptions{Ordered: true})
+		}},
+	}
+
+	wrr := warning.New("test")
+
+	for _, bc := range collectors {
+		b.Run(bc.name, func(b *testing.B) {
+			collector := bc.newCollector()
+			defer collector.Close()
+
+			// drain the collector while writing, so that the benchmark measures contention on the locks
+			// rather than the growth of the buffers
+			done := make(chan struct{})
+
+			var wg sync.WaitGroup
+
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				for {
+					select {
+					case <-done:
+						return
+					default:
+					}
+
+					if _, err := collector.ReadWarning(); err != nil {
+						runtime.Gosched()
+					}
+				}
+			}()
+
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					_ = collector.WriteWarning(wrr)
+				}
+			})
+
+			b.StopTimer()
+			close(done)
+			wg.Wait()
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package warning
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// SourceFunc returns the content of the named source file. [os.ReadFile] satisfies this signature.
+type SourceFunc func(filename string) ([]byte, error)
+
+// RenderSnippet writes the warning in compiler style, followed by the source line it refers to
+// and a caret marking the column or range. Related notes are rendered the same way and the hint
+// is written last, followed by the documentation URL of the warning code:
+//
+//	config.toml:12:5: warning[W1042]: unknown key "tiemout"
+//	 12 | tiemout = "5s"
+//	    |     ^^^^^^^
+//	hint: did you mean "timeout"?
+//	see https://example.com/warnings/W1042
+//
+// The source content is loaded with src. If src is nil, the warning has no position,
+// or the source cannot be loaded, the source line is omitted.
+func RenderSnippet(w io.Writer, wrr Warning, src SourceFunc) error {
+	var buf bytes.Buffer
+
+	rng, hasRange := RangeOf(wrr)
+	path, _ := PathOf(wrr)
+
+	label := SeverityOf(wrr).String()
+	if code := CodeOf(wrr); code != "" {
+		label += "[" + code + "]"
+	}
+
+	writeEntry(&buf, label, wrr.Warn(), rng, hasRange, path, src)
+
+	for _, note := range NotesOf(wrr) {
+		hasNoteRange := note.Range.Start.IsValid() || note.Range.Start.Filename != ""
+		writeEntry(&buf, "note", note.Message, note.Range, hasNoteRange, nil, src)
+	}
+
+	if hint := HintOf(wrr); hint != "" {
+		buf.WriteString("hint: ")
+		buf.WriteString(hint)
+		buf.WriteByte('\n')
+	}
+
+	if def, ok := DefinitionOf(wrr); ok && def.URL != "" {
+		buf.WriteString("see ")
+		buf.WriteString(def.URL)
+		buf.WriteByte('\n')
+	}
+
+	_, err := w.Write(buf.Bytes())
+
+	return err
+}
+
+func writeEntry(buf *bytes.Buffer, label, msg string, rng Range, hasRange bool, path Path, src SourceFunc) {
+	var content []byte
+
+	if hasRange && src != nil && rng.Start.Filename != "" {
+		if data, err := src(rng.Start.Filename); err == nil {
+			content = data
+			rng = resolveRange(content, rng)
+		}
+	}
+
+	switch {
+	case hasRange:
+		buf.WriteString(rng.String())
+		buf.WriteString(": ")
+	case len(path) > 0:
+		buf.WriteString(path.String())
+		buf.WriteString(": ")
+	}
+
+	buf.WriteString(label)
+	buf.WriteString(": ")
+	buf.WriteString(msg)
+	buf.WriteByte('\n')
+
+	if content != nil {
+		writeSnippet(buf, content, rng)
+	}
+}
+
+func resolveRange(src []byte, rng Range) Range {
+	rng.Start, _ = resolve(src, rng.Start)
+
+	if rng.End.IsValid() || rng.End.Offset > 0 {
+		rng.End, _ = resolve(src, rng.End)
+	}
+
+	return rng
+}
+
+func writeSnippet(buf *bytes.Buffer, src []byte, rng Range) {
+	start, end, ok := lineBounds(src, rng.Start.Line)
+	if !ok {
+		return
+	}
+
+	line := string(src[start:end])
+	num := strconv.Itoa(rng.Start.Line)
+	gutter := strings.Repeat(" ", len(num))
+
+	col := min(max(rng.Start.Column, 1)-1, len(line))
+
+	width := 1
+
+	switch {
+	case rng.End.Line == rng.Start.Line && rng.End.Column > rng.Start.Column:
+		width = min(rng.End.Column-1, len(line)) - col
+	case rng.End.Line > rng.Start.Line:
+		width = len(line) - col
+	}
+
+	// keep tabs from the source line so the caret lines up with the text above it
+	pad := []byte(line[:col])
+	for i, c := range pad {
+		if c != '\t' {
+			pad[i] = ' '
+		}
+	}
+
+	fmt.Fprintf(buf, " %s | %s\n", num, line)
+	fmt.Fprintf(buf, " %s | %s%s\n", gutter, pad, strings.Repeat("^", max(width, 1)))
+}
+
+type snippetWriter struct {
+	w   io.Writer
+	src SourceFunc
+}
+
+// NewSnippetWriter returns a Writer that renders each warning using [RenderSnippet].
+func NewSnippetWriter(w io.Writer, src SourceFunc) Writer {
+	return &snippetWriter{w, src}
+}
+
+func (writer *snippetWriter) WriteWarning(wrr Warning) error {
+	return RenderSnippet(writer.w, wrr, writer.src)
+}
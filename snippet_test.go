@@ -0,0 +1,139 @@
+package warning_test
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"testing"
+
+	"go.wamod.dev/warning"
+)
+
+func mockSource(files map[string]string) warning.SourceFunc {
+	return func(filename string) ([]byte, error) {
+		content, ok := files[filename]
+		if !ok {
+			return nil, os.ErrNotExist
+		}
+
+		return []byte(content), nil
+	}
+}
+
+// ExampleRenderSnippet demonstrates how to render a warning together with the source it refers to.
+func ExampleRenderSnippet() {
+	src := mockSource(map[string]string{
+		"config.toml": "[server]\ntiemout = \"5s\"\n",
+	})
+
+	wrr := warning.WithRange(warning.New(`unknown key "tiemout"`), warning.Range{
+		Start: warning.Position{Filename: "config.toml", Line: 2, Column: 1},
+		End:   warning.Position{Filename: "config.toml", Line: 2, Column: 8},
+	})
+
+	if err := warning.RenderSnippet(os.Stdout, wrr, src); err != nil {
+		panic(err)
+	}
+
+	// Output:
+	// config.toml:2:1-2:8: warning: unknown key "tiemout"
+	//  2 | tiemout = "5s"
+	//    | ^^^^^^^
+}
+
+func TestRenderSnippet(t *testing.T) {
+	src := mockSource(map[string]string{
+		"a.conf": "first\n\tkey = value\nlast",
+	})
+
+	tests := []struct {
+		name string
+		wrr  warning.Warning
+		want string
+	}{
+		{
+			name: "no position",
+			wrr:  warning.New("test"),
+			want: "warning: test\n",
+		},
+		{
+			name: "path",
+			wrr:  warning.WithPath(warning.New("test"), warning.Path{}.Field("key")),
+			want: "key: warning: test\n",
+		},
+		{
+			name: "column",
+			wrr:  warning.WithPosition(warning.New("test"), warning.Position{Filename: "a.conf", Line: 2, Column: 8}),
+			want: "a.conf:2:8: warning: test\n 2 | \tkey = value\n   | \t      ^\n",
+		},
+		{
+			name: "offset",
+			wrr:  warning.WithPosition(warning.New("test"), warning.Position{Filename: "a.conf", Offset: 7}),
+			want: "a.conf:2:2: warning: test\n 2 | \tkey = value\n   | \t^\n",
+		},
+		{
+			name: "multi-line",
+			wrr: warning.WithRange(warning.New("test"), warning.Range{
+				Start: warning.Position{Filename: "a.conf", Line: 2, Column: 8},
+				End:   warning.Position{Filename: "a.conf", Line: 3, Column: 2},
+			}),
+			want: "a.conf:2:8-3:2: warning: test\n 2 | \tkey = value\n   | \t      ^^^^^\n",
+		},
+		{
+			name: "notes and hint",
+			wrr: warning.WithHint(warning.WithNotes(
+				warning.WithPosition(warning.New("duplicate key"), warning.Position{Filename: "a.conf", Line: 2, Column: 2}),
+				warning.Note{Message: "previous definition here", Range: warning.Range{Start: warning.Position{Filename: "a.conf", Line: 1, Column: 1}}},
+				warning.Note{Message: "keys are case sensitive"},
+			), "remove one of the keys"),
+			want: "a.conf:2:2: warning: duplicate key\n 2 | \tkey = value\n   | \t^\n" +
+				"a.conf:1:1: note: previous definition here\n 1 | first\n   | ^\n" +
+				"note: keys are case sensitive\n" +
+				"hint: remove one of the keys\n",
+		},
+		{
+			name: "code",
+			wrr: warning.NewRegistry().MustRegister(warning.Definition{
+				Code:     "W1042",
+				Severity: warning.SeverityError,
+				URL:      "https://example.com/W1042",
+			}).New("test"),
+			want: "error[W1042]: test\nsee https://example.com/W1042\n",
+		},
+		{
+			name: "missing source",
+			wrr:  warning.WithPosition(warning.New("test"), warning.Position{Filename: "b.conf", Line: 1, Column: 1}),
+			want: "b.conf:1:1: warning: test\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			if err := warning.RenderSnippet(&buf, tt.wrr, src); err != nil {
+				t.Fatalf("expected nil error, got %v", err)
+			}
+
+			if got := buf.String(); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestNewSnippetWriter(t *testing.T) {
+	var buf bytes.Buffer
+
+	writer := warning.NewSnippetWriter(&buf, nil)
+
+	for i := range 2 {
+		if err := writer.WriteWarning(warning.New(fmt.Sprint("test-", i))); err != nil {
+			t.Fatalf("expected nil error, got %v", err)
+		}
+	}
+
+	if got, want := buf.String(), "warning: test-0\nwarning: test-1\n"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
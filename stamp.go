@@ -0,0 +1,191 @@
+package warning
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sync/atomic"
+	"time"
+)
+
+// Clock returns the current time. [time.Now] satisfies this signature.
+type Clock func() time.Time
+
+type clockKey struct{}
+
+var sequence atomic.Uint64 //nolint:gochecknoglobals
+
+// WithClock returns a new context in which collectors stamp the warnings written to it with the time returned
+// by clock instead of [time.Now], e.g. to make timestamps deterministic in tests. If clock is nil, time.Now is used.
+func WithClock(ctx context.Context, clock Clock) context.Context {
+	if clock == nil {
+		clock = time.Now
+	}
+
+	return context.WithValue(ctx, clockKey{}, clock)
+}
+
+// clockFrom returns the clock set by [WithClock], or [time.Now] if none is set.
+func clockFrom(ctx context.Context) Clock {
+	if clock, ok := ctx.Value(clockKey{}).(Clock); ok {
+		return clock
+	}
+
+	return time.Now
+}
+
+// stamp returns the warning stamped with the next sequence number and the current time of clock.
+func stamp(wrr Warning, clock Clock) Warning {
+	if _, stamped := find[*stampWarning](wrr); stamped {
+		return wrr
+	}
+
+	return &stampWarning{wrr, sequence.Add(1), clock()}
+}
+
+type stampWarning struct {
+	wrr  Warning
+	seq  uint64
+	time time.Time
+}
+
+func (wrr *stampWarning) Warn() string {
+	return wrr.wrr.Warn()
+}
+
+func (wrr *stampWarning) Format(state fmt.State, verb rune) {
+	formatState(state, verb, wrr)
+}
+
+func (wrr *stampWarning) String() string {
+	return stringOf(wrr.wrr)
+}
+
+func (wrr *stampWarning) MarshalJSON() ([]byte, error) {
+	return json.Marshal(wrr.wrr)
+}
+
+func (wrr *stampWarning) Sequence() uint64 {
+	return wrr.seq
+}
+
+func (wrr *stampWarning) Time() time.Time {
+	return wrr.time
+}
+
+func (wrr *stampWarning) Unwrap() Warning {
+	return wrr.wrr
+}
+
+// SequenceOf returns the sequence number the warning was stamped with when it was collected.
+func SequenceOf(wrr Warning) (uint64, bool) {
+	found, ok := find[interface{ Sequence() uint64 }](wrr)
+	if !ok {
+		return 0, false
+	}
+
+	return found.Sequence(), true
+}
+
+// TimeOf returns the time the warning was stamped with when it was collected.
+func TimeOf(wrr Warning) (time.Time, bool) {
+	found, ok := find[interface{ Time() time.Time }](wrr)
+	if !ok {
+		return time.Time{}, false
+	}
+
+	return found.Time(), true
+}
+
+// Compare orders warnings by the time they were stamped with, then by their sequence number.
+// Warnings without a stamp are ordered after stamped ones and are equal to each other.
+func Compare(a, b Warning) int {
+	aTime, aOK := TimeOf(a)
+	bTime, bOK := TimeOf(b)
+
+	switch {
+	case !aOK || !bOK:
+		return boolCompare(!aOK, !bOK)
+	case !aTime.Equal(bTime):
+		return aTime.Compare(bTime)
+	}
+
+	aSeq, _ := SequenceOf(a)
+	bSeq, _ := SequenceOf(b)
+
+	switch {
+	case aSeq < bSeq:
+		return -1
+	case aSeq > bSeq:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func boolCompare(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case b:
+		return -1
+	default:
+		return 1
+	}
+}
+
+// Sort sorts the warnings using [Compare]. The sort is stable, warnings that compare equal keep their order.
+func Sort(wrrs []Warning) {
+	slices.SortStableFunc(wrrs, Compare)
+}
+
+type mergeReader struct {
+	readers []Reader
+	heads   []Warning
+}
+
+// MergeOrdered returns a Reader that merges the warnings of the readers into a single stream ordered by
+// [Compare]. Each reader is expected to return its own warnings in order, as a [Collector] does for
+// warnings written from a single goroutine.
+//
+// Reading never blocks: if a reader returns [io.EOF], it is asked again on the next read, and the merged
+// reader returns io.EOF once none of the readers has a warning available. Other errors are returned as is.
+func MergeOrdered(readers ...Reader) Reader {
+	return &mergeReader{readers, make([]Warning, len(readers))}
+}
+
+func (r *mergeReader) ReadWarning() (Warning, error) {
+	found := -1
+
+	for i, reader := range r.readers {
+		if r.heads[i] == nil {
+			wrr, err := reader.ReadWarning()
+
+			switch {
+			case errors.Is(err, io.EOF):
+				continue
+			case err != nil:
+				return nil, err
+			}
+
+			r.heads[i] = wrr
+		}
+
+		// on ties, the earlier reader wins so the merge is stable
+		if found < 0 || Compare(r.heads[i], r.heads[found]) < 0 {
+			found = i
+		}
+	}
+
+	if found < 0 {
+		return nil, io.EOF
+	}
+
+	wrr := r.heads[found]
+	r.heads[found] = nil
+
+	return wrr, nil
+}
@@ -0,0 +1,235 @@
+package warning_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"go.wamod.dev/warning"
+)
+
+// fakeClock returns times one second apart, starting at the given time.
+func fakeClock(start time.Time) warning.Clock {
+	now := start
+
+	return func() time.Time {
+		now = now.Add(time.Second)
+
+		return now
+	}
+}
+
+// ExampleMergeOrdered demonstrates how to merge warnings captured by separate collectors in order.
+func ExampleMergeOrdered() {
+	ctx := context.Background()
+
+	first := warning.NewCollector()
+	defer first.Close()
+
+	second := warning.NewCollector()
+	defer second.Close()
+
+	warning.Warn(warning.Attach(ctx, first), warning.New("one"))
+	warning.Warn(warning.Attach(ctx, second), warning.New("two"))
+	warning.Warn(warning.Attach(ctx, first), warning.New("three"))
+
+	wrrs, err := warning.ReadAll(warning.MergeOrdered(first, second))
+	if err != nil {
+		panic(err)
+	}
+
+	for _, wrr := range wrrs {
+		fmt.Println(wrr.Warn())
+	}
+
+	// Output:
+	// one
+	// two
+	// three
+}
+
+func TestWithClock(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	collector := warning.NewCollector()
+
+	ctx := warning.WithClock(warning.Attach(context.Background(), collector), fakeClock(start))
+
+	warning.Warn(ctx, warning.New("first"), warning.New("second"))
+	warning.Warnf(ctx, "third")
+
+	wrrs, err := warning.ReadAll(collector)
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	if len(wrrs) != 3 {
+		t.Fatalf("expected 3 warnings, got %v", wrrs)
+	}
+
+	var prev uint64
+
+	for i, wrr := range wrrs {
+		seq, ok := warning.SequenceOf(wrr)
+		if !ok || seq <= prev {
+			t.Errorf("expected increasing sequence number, got %v after %v", seq, prev)
+		}
+
+		prev = seq
+
+		want := start.Add(time.Duration(i+1) * time.Second)
+		if got, ok := warning.TimeOf(wrr); !ok || !got.Equal(want) {
+			t.Errorf("expected time %v, got %v", want, got)
+		}
+	}
+
+	// warnings are stamped only once
+	warning.Warn(ctx, wrrs[0])
+
+	if got, err := collector.ReadWarning(); err != nil || got != wrrs[0] {
+		t.Errorf("expected stamped warning to be collected as is, got %v, %v", got, err)
+	}
+
+	if _, ok := warning.SequenceOf(warning.New("test")); ok {
+		t.Errorf("expected no sequence number")
+	}
+
+	if _, ok := warning.TimeOf(warning.New("test")); ok {
+		t.Errorf("expected no time")
+	}
+}
+
+func TestStampForwarding(t *testing.T) {
+	want := warning.WithCode(warning.New("test"), "W1")
+	writer := &mockWriter{}
+
+	// warnings are stamped once collected, writers and filters in front of the collector see them as written
+	ctx := warning.Attach(context.Background(), warning.NewCollector())
+	ctx = warning.Tap(ctx, func(wrr warning.Warning) { writer.WriteWarning(wrr) })
+
+	warning.Warn(ctx, want)
+
+	if len(writer.buf) != 1 || writer.buf[0] != want {
+		t.Fatalf("expected %v, got %v", want, writer.buf)
+	}
+
+	sharded := warning.NewShardedCollector(warning.ShardedCollectorOptions{})
+	warning.Warn(warning.Attach(context.Background(), sharded), want)
+
+	got, err := sharded.ReadWarning()
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	if _, ok := warning.SequenceOf(got); !ok {
+		t.Errorf("expected warning to have a sequence number")
+	}
+
+	if found, ok := warning.As[interface{ Code() string }](got); !ok || found.(warning.Warning) != want {
+		t.Errorf("expected %v, got %v", want, found)
+	}
+
+	if _, ok := warning.As[*mockWriter](got); ok {
+		t.Errorf("expected no match")
+	}
+
+	collector := warning.NewCollector()
+	warning.Warn(warning.Attach(context.Background(), collector), warning.New("test"))
+
+	got, err = collector.ReadWarning()
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	if s, ok := got.(fmt.Stringer); !ok || s.String() != "test" {
+		t.Errorf("expected stamped warning to be a fmt.Stringer returning test, got %v", got)
+	}
+
+	data, err := json.Marshal(got)
+	if err != nil || string(data) != `"test"` {
+		t.Errorf(`expected "test", got %s, %v`, data, err)
+	}
+}
+
+func TestSort(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	collector := warning.NewCollector()
+
+	ctx := warning.Attach(context.Background(), collector)
+	sameTime := warning.WithClock(ctx, func() time.Time { return start })
+	later := warning.WithClock(ctx, func() time.Time { return start.Add(time.Hour) })
+
+	warning.Warn(later, warning.New("c"))
+	warning.Warn(sameTime, warning.New("a"))
+	warning.Warn(sameTime, warning.New("b"))
+
+	collected, err := warning.ReadAll(collector)
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	wrrs := []warning.Warning{warning.New("x"), collected[0], warning.New("y"), collected[2], collected[1]}
+	warning.Sort(wrrs)
+
+	want := []string{"a", "b", "c", "x", "y"}
+	for i, wrr := range wrrs {
+		if wrr.Warn() != want[i] {
+			t.Errorf("expected %v at %d, got %v", want[i], i, wrr.Warn())
+		}
+	}
+}
+
+func TestMergeOrdered(t *testing.T) {
+	ctx := warning.WithClock(context.Background(), fakeClock(time.Now()))
+
+	readers := []*warning.Collector{warning.NewCollector(), warning.NewCollector(), warning.NewCollector()}
+	for _, i := range []int{0, 1, 1, 0, 2, 0} {
+		warning.Warnf(warning.Attach(ctx, readers[i]), "%d", i)
+	}
+
+	merged := warning.MergeOrdered(readers[0], readers[1], readers[2])
+
+	for _, want := range []string{"0", "1", "1", "0"} {
+		wrr, err := merged.ReadWarning()
+		if err != nil {
+			t.Fatalf("expected nil error, got %v", err)
+		}
+
+		if wrr.Warn() != want {
+			t.Errorf("expected %v, got %v", want, wrr.Warn())
+		}
+	}
+
+	// warnings written after the merge started are still read
+	warning.Warnf(warning.Attach(ctx, readers[1]), "late")
+
+	wrrs, err := warning.ReadAll(merged)
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	want := []string{"2", "0", "late"}
+	if len(wrrs) != len(want) {
+		t.Fatalf("expected %v, got %v", want, wrrs)
+	}
+
+	for i, wrr := range wrrs {
+		if wrr.Warn() != want[i] {
+			t.Errorf("expected %v at %d, got %v", want[i], i, wrr.Warn())
+		}
+	}
+
+	readers[2].Close()
+	warning.Warnf(warning.Attach(ctx, readers[0]), "test")
+
+	if _, err := merged.ReadWarning(); !errors.Is(err, warning.ErrClosed) {
+		t.Errorf("expected %v, got %v", warning.ErrClosed, err)
+	}
+
+	if _, err := warning.MergeOrdered().ReadWarning(); !errors.Is(err, io.EOF) {
+		t.Errorf("expected %v, got %v", io.EOF, err)
+	}
+}
@@ -0,0 +1,78 @@
+package warning
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+)
+
+// Tag classifies a warning, for example as a deprecation.
+type Tag string
+
+const (
+	// TagDeprecation marks warnings about the use of deprecated features.
+	TagDeprecation Tag = "deprecation"
+	// TagUnnecessary marks warnings about unused or unnecessary code.
+	TagUnnecessary Tag = "unnecessary"
+	// TagLate marks warnings written to a context that was already done, see [OnDone].
+	TagLate Tag = "late"
+)
+
+type tagWarning struct {
+	wrr  Warning
+	tags []Tag
+}
+
+// WithTags returns a warning with the given tags added to the tags it already has.
+func WithTags(wrr Warning, tags ...Tag) Warning {
+	return &tagWarning{wrr, tags}
+}
+
+func (wrr *tagWarning) Warn() string {
+	return wrr.wrr.Warn()
+}
+
+func (wrr *tagWarning) Format(state fmt.State, verb rune) {
+	formatState(state, verb, wrr)
+}
+
+func (wrr *tagWarning) String() string {
+	return stringOf(wrr.wrr)
+}
+
+func (wrr *tagWarning) MarshalJSON() ([]byte, error) {
+	return json.Marshal(wrr.wrr)
+}
+
+func (wrr *tagWarning) Tags() []Tag {
+	return wrr.tags
+}
+
+func (wrr *tagWarning) Unwrap() Warning {
+	return wrr.wrr
+}
+
+// TagsOf returns the tags of the warning and all the warnings it wraps, without duplicates.
+func TagsOf(wrr Warning) []Tag {
+	var tags []Tag
+
+	for ; wrr != nil; wrr = Unwrap(wrr) {
+		tagged, ok := wrr.(interface{ Tags() []Tag })
+		if !ok {
+			continue
+		}
+
+		for _, tag := range tagged.Tags() {
+			if !slices.Contains(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+	}
+
+	return tags
+}
+
+// HasTag reports whether the warning or any warning it wraps has the tag.
+func HasTag(wrr Warning, tag Tag) bool {
+	return slices.Contains(TagsOf(wrr), tag)
+}
@@ -0,0 +1,49 @@
+package warning_test
+
+import (
+	"slices"
+	"testing"
+
+	"go.wamod.dev/warning"
+)
+
+func TestWithTags(t *testing.T) {
+	inner := warning.New("test")
+	wrr := warning.WithTags(inner, warning.TagDeprecation)
+
+	if wrr.Warn() != "test" {
+		t.Errorf("expected test, got %v", wrr.Warn())
+	}
+
+	if got := warning.Unwrap(wrr); got != inner {
+		t.Errorf("expected %v, got %v", inner, got)
+	}
+
+	wrr = warning.WithTags(wrr, warning.TagUnnecessary, warning.TagDeprecation)
+
+	want := []warning.Tag{warning.TagUnnecessary, warning.TagDeprecation}
+	if got := warning.TagsOf(wrr); !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	if !warning.HasTag(wrr, warning.TagDeprecation) {
+		t.Errorf("expected %v tag", warning.TagDeprecation)
+	}
+
+	if warning.HasTag(inner, warning.TagDeprecation) {
+		t.Errorf("expected no %v tag", warning.TagDeprecation)
+	}
+
+	requireForwarded(t, wrr, "test")
+}
+
+func TestDefinition_Tags(t *testing.T) {
+	def := warning.NewRegistry().MustRegister(warning.Definition{
+		Code: "W1",
+		Tags: []warning.Tag{warning.TagDeprecation},
+	})
+
+	if wrr := def.New("test"); !warning.HasTag(wrr, warning.TagDeprecation) {
+		t.Errorf("expected %v tag", warning.TagDeprecation)
+	}
+}
@@ -0,0 +1,347 @@
+package warning
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ColorMode selects when [RenderText] colours its output.
+type ColorMode int
+
+const (
+	// ColorAuto colours the output when it is written to a terminal, unless the NO_COLOR
+	// environment variable is set to a non-empty value.
+	ColorAuto ColorMode = iota
+	// ColorNever never colours the output.
+	ColorNever
+	// ColorAlways always colours the output.
+	ColorAlways
+)
+
+// TextOptions controls how [RenderText] renders warnings.
+type TextOptions struct {
+	// GroupBy selects the heading warnings are listed under. With [GroupByNone],
+	// warnings are listed without headings.
+	GroupBy GroupBy
+	// Width is the number of characters messages are wrapped to. Zero disables wrapping.
+	Width int
+	// Color selects when severities and headings are coloured.
+	Color ColorMode
+}
+
+// minTextWidth is the least number of characters a message is wrapped to, however narrow the output.
+const minTextWidth = 20
+
+// RenderText writes the warnings read from r to w for people reading a terminal. Warnings are grouped
+// as selected by opts.GroupBy, with the location, severity and code of each warning aligned in columns.
+// The notes and the hint of a warning are listed below its message, and a summary counting all warnings,
+// errors, deprecations and files is written last:
+//
+//	config.toml
+//	  12:5  warning  W1042  unknown key "tiemout"
+//	                        hint: did you mean "timeout"?
+//	  20:1  warning  W1001  option "retries" is deprecated
+//	                        note: config.toml:8:1: previous definition here
+//
+//	main.go
+//	  3:10  error    E1     missing value
+//
+//	3 warnings, 1 error, 1 deprecation in 2 files
+//
+// Columns that are the heading already, such as the file name when grouping by file, are left out.
+func RenderText(w io.Writer, r Reader, opts TextOptions) error {
+	wrrs, err := ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	groups := []warningGroup{{wrrs: wrrs}}
+	if opts.GroupBy != GroupByNone {
+		groups = groupWarnings(wrrs, opts.GroupBy)
+	}
+
+	rows := make([][]textRow, len(groups))
+
+	var widths [3]int
+
+	for i, group := range groups {
+		for _, wrr := range group.wrrs {
+			row := newTextRow(wrr, opts.GroupBy)
+
+			for col, text := range row.cols {
+				widths[col] = max(widths[col], utf8.RuneCountInString(text))
+			}
+
+			rows[i] = append(rows[i], row)
+		}
+	}
+
+	var indent string
+	if opts.GroupBy != GroupByNone {
+		indent = "  "
+	}
+
+	// the message starts after the indentation and the columns that are not empty
+	msgCol := len(indent)
+	for _, width := range widths {
+		if width > 0 {
+			msgCol += width + 2
+		}
+	}
+
+	wrapWidth := 0
+	if opts.Width > 0 {
+		wrapWidth = max(opts.Width-msgCol, minTextWidth)
+	}
+
+	style := textStyle{colorEnabled(w, opts.Color)}
+
+	var buf bytes.Buffer
+
+	for i, group := range groups {
+		if opts.GroupBy != GroupByNone {
+			buf.WriteString(style.apply(sgrBold, groupName(group.key, opts.GroupBy)))
+			buf.WriteByte('\n')
+		}
+
+		for _, row := range rows[i] {
+			buf.WriteString(indent)
+
+			for col, text := range row.cols {
+				if widths[col] == 0 {
+					continue
+				}
+
+				styled := text
+				if col == textColSeverity {
+					styled = style.apply(severityColor(row.severity), text)
+				}
+
+				buf.WriteString(styled)
+				buf.WriteString(strings.Repeat(" ", widths[col]-utf8.RuneCountInString(text)+2))
+			}
+
+			lines := wrapText(row.msg, wrapWidth)
+			for _, note := range row.notes {
+				lines = append(lines, wrapText("note: "+note, wrapWidth)...)
+			}
+
+			if row.hint != "" {
+				lines = append(lines, wrapText("hint: "+row.hint, wrapWidth)...)
+			}
+
+			for j, line := range lines {
+				if j > 0 {
+					buf.WriteString(strings.Repeat(" ", msgCol))
+				}
+
+				buf.WriteString(line)
+				buf.WriteByte('\n')
+			}
+		}
+
+		buf.WriteByte('\n')
+	}
+
+	if len(wrrs) == 0 {
+		buf.Reset()
+	}
+
+	buf.WriteString(style.apply(sgrBold, textSummary(wrrs)))
+	buf.WriteByte('\n')
+
+	_, err = w.Write(buf.Bytes())
+
+	return err
+}
+
+const (
+	textColLocation = iota
+	textColSeverity
+	textColCode
+)
+
+type textRow struct {
+	cols      [3]string
+	severity  Severity
+	msg, hint string
+	notes     []string
+}
+
+func newTextRow(wrr Warning, by GroupBy) textRow {
+	row := textRow{severity: SeverityOf(wrr), msg: wrr.Warn(), hint: HintOf(wrr)}
+
+	for _, note := range NotesOf(wrr) {
+		row.notes = append(row.notes, noteText(note))
+	}
+
+	if rng, ok := RangeOf(wrr); ok {
+		if by == GroupByFile {
+			rng.Start.Filename, rng.End.Filename = "", ""
+		}
+
+		row.cols[textColLocation] = rng.String()
+	} else if path, ok := PathOf(wrr); ok && len(path) > 0 {
+		row.cols[textColLocation] = path.String()
+	}
+
+	row.cols[textColSeverity] = row.severity.String()
+
+	if by != GroupByCode {
+		row.cols[textColCode] = CodeOf(wrr)
+	}
+
+	return row
+}
+
+// textSummary counts the warnings, and how many of them are errors, deprecations and distinct files.
+func textSummary(wrrs []Warning) string {
+	if len(wrrs) == 0 {
+		return "no warnings"
+	}
+
+	var errs, deprecations int
+
+	files := make(map[string]bool)
+
+	for _, wrr := range wrrs {
+		if SeverityOf(wrr) >= SeverityError {
+			errs++
+		}
+
+		if HasTag(wrr, TagDeprecation) {
+			deprecations++
+		}
+
+		if rng, ok := RangeOf(wrr); ok && rng.Start.Filename != "" {
+			files[rng.Start.Filename] = true
+		}
+	}
+
+	parts := []string{plural(len(wrrs), "warning")}
+
+	if errs > 0 {
+		parts = append(parts, plural(errs, "error"))
+	}
+
+	if deprecations > 0 {
+		parts = append(parts, plural(deprecations, "deprecation"))
+	}
+
+	summary := strings.Join(parts, ", ")
+	if len(files) > 0 {
+		summary += " in " + plural(len(files), "file")
+	}
+
+	return summary
+}
+
+func plural(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+
+	return strconv.Itoa(n) + " " + noun + "s"
+}
+
+// wrapText splits text into lines of at most width characters, breaking at spaces.
+// Words longer than width are not broken. If width is zero, only line breaks in text split it.
+func wrapText(text string, width int) []string {
+	var lines []string
+
+	for _, paragraph := range strings.Split(text, "\n") {
+		if width <= 0 {
+			lines = append(lines, paragraph)
+
+			continue
+		}
+
+		var line strings.Builder
+
+		n := 0
+
+		for _, word := range strings.Fields(paragraph) {
+			size := utf8.RuneCountInString(word)
+
+			if n > 0 && n+1+size > width {
+				lines = append(lines, line.String())
+				line.Reset()
+
+				n = 0
+			}
+
+			if n > 0 {
+				line.WriteByte(' ')
+				n++
+			}
+
+			line.WriteString(word)
+			n += size
+		}
+
+		lines = append(lines, line.String())
+	}
+
+	return lines
+}
+
+// Select Graphic Rendition parameters of ANSI escape sequences.
+const (
+	sgrBold   = "1"
+	sgrRed    = "1;31"
+	sgrYellow = "1;33"
+	sgrCyan   = "1;36"
+	sgrBlue   = "1;34"
+)
+
+func severityColor(severity Severity) string {
+	switch {
+	case severity >= SeverityError:
+		return sgrRed
+	case severity == SeverityWarning:
+		return sgrYellow
+	case severity == SeverityInfo:
+		return sgrCyan
+	default:
+		return sgrBlue
+	}
+}
+
+type textStyle struct {
+	color bool
+}
+
+func (style textStyle) apply(sgr, text string) string {
+	if !style.color || text == "" {
+		return text
+	}
+
+	return "\x1b[" + sgr + "m" + text + "\x1b[0m"
+}
+
+// colorEnabled reports whether output written to w is coloured with the given mode.
+func colorEnabled(w io.Writer, mode ColorMode) bool {
+	switch mode {
+	case ColorAlways:
+		return true
+	case ColorNever:
+		return false
+	}
+
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+
+	file, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+
+	info, err := file.Stat()
+
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
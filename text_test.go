@@ -0,0 +1,189 @@
+package warning_test
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"go.wamod.dev/warning"
+)
+
+// ExampleRenderText demonstrates how to show collected warnings to a user.
+func ExampleRenderText() {
+	wrrs := []warning.Warning{
+		warning.WithHint(
+			warning.WithPosition(warning.WithCode(warning.New(`unknown key "tiemout"`), "W1042"),
+				warning.Position{Filename: "config.toml", Line: 12, Column: 5}),
+			`did you mean "timeout"?`,
+		),
+		warning.WithTags(
+			warning.WithNotes(
+				warning.WithPosition(warning.WithCode(warning.New(`option "retries" is deprecated`), "W1001"),
+					warning.Position{Filename: "config.toml", Line: 20, Column: 1}),
+				warning.Note{Message: "previous definition here", Range: warning.Range{
+					Start: warning.Position{Filename: "config.toml", Line: 8, Column: 1},
+				}},
+			),
+			warning.TagDeprecation,
+		),
+		warning.WithSeverity(
+			warning.WithPosition(warning.WithCode(warning.New("missing value"), "E1"),
+				warning.Position{Filename: "main.go", Line: 3, Column: 10}),
+			warning.SeverityError,
+		),
+	}
+
+	err := warning.RenderText(os.Stdout, warning.NewSliceReader(wrrs), warning.TextOptions{
+		GroupBy: warning.GroupByFile,
+		Color:   warning.ColorNever,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	// Output:
+	// config.toml
+	//   12:5  warning  W1042  unknown key "tiemout"
+	//                         hint: did you mean "timeout"?
+	//   20:1  warning  W1001  option "retries" is deprecated
+	//                         note: config.toml:8:1: previous definition here
+	//
+	// main.go
+	//   3:10  error    E1     missing value
+	//
+	// 3 warnings, 1 error, 1 deprecation in 2 files
+}
+
+func renderText(t *testing.T, wrrs []warning.Warning, opts warning.TextOptions) string {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	if err := warning.RenderText(&buf, warning.NewSliceReader(wrrs), opts); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	return buf.String()
+}
+
+func TestRenderText(t *testing.T) {
+	tests := []struct {
+		name string
+		wrrs []warning.Warning
+		opts warning.TextOptions
+		want string
+	}{
+		{
+			name: "empty",
+			want: "no warnings\n",
+		},
+		{
+			name: "no grouping",
+			wrrs: []warning.Warning{
+				warning.New("first"),
+				warning.WithSeverity(warning.WithPath(warning.New("second"), warning.Path{}.Field("servers").Index(0)), warning.SeverityInfo),
+			},
+			want: "            warning  first\n" +
+				"servers[0]  info     second\n" +
+				"\n" +
+				"2 warnings\n",
+		},
+		{
+			name: "by scope",
+			wrrs: []warning.Warning{
+				scoped("db", warning.WithCode(warning.New("slow query"), "DB1")),
+				warning.WithPosition(warning.New("unscoped"), warning.Position{Filename: "a.go", Line: 1, Column: 2}),
+			},
+			opts: warning.TextOptions{GroupBy: warning.GroupByScope},
+			want: "db\n" +
+				"            warning  DB1  slow query\n" +
+				"\n" +
+				"(no scope)\n" +
+				"  a.go:1:2  warning       unscoped\n" +
+				"\n" +
+				"2 warnings in 1 file\n",
+		},
+		{
+			name: "by code",
+			wrrs: []warning.Warning{
+				warning.WithCode(warning.New("first"), "W1"),
+				warning.WithCode(warning.New("second"), "W1"),
+			},
+			opts: warning.TextOptions{GroupBy: warning.GroupByCode},
+			want: "W1\n" +
+				"  warning  first\n" +
+				"  warning  second\n" +
+				"\n" +
+				"2 warnings\n",
+		},
+		{
+			name: "wrapped",
+			wrrs: []warning.Warning{
+				warning.New("the quick brown fox jumps over the lazy dog\nand then an unbreakablewordthatislong"),
+			},
+			opts: warning.TextOptions{Width: 30},
+			want: "warning  the quick brown fox\n" +
+				"         jumps over the lazy\n" +
+				"         dog\n" +
+				"         and then an\n" +
+				"         unbreakablewordthatislong\n" +
+				"\n" +
+				"1 warning\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := renderText(t, tt.wrrs, tt.opts); got != tt.want {
+				t.Errorf("expected\n%s\ngot\n%s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRenderText_Color(t *testing.T) {
+	wrrs := []warning.Warning{
+		warning.WithSeverity(warning.New("broken"), warning.SeverityError),
+		warning.New("suspicious"),
+	}
+
+	got := renderText(t, wrrs, warning.TextOptions{Color: warning.ColorAlways})
+	for _, want := range []string{"\x1b[1;31merror\x1b[0m    broken\n", "\x1b[1;33mwarning\x1b[0m  suspicious\n", "\x1b[1m2 warnings, 1 error\x1b[0m\n"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected output to contain %q, got %q", want, got)
+		}
+	}
+
+	// a buffer is not a terminal
+	if got := renderText(t, wrrs, warning.TextOptions{}); strings.Contains(got, "\x1b[") {
+		t.Errorf("expected no colour, got %q", got)
+	}
+
+	if got := renderText(t, wrrs, warning.TextOptions{Color: warning.ColorNever}); strings.Contains(got, "\x1b[") {
+		t.Errorf("expected no colour, got %q", got)
+	}
+}
+
+func TestRenderText_NoColorEnv(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+
+	file, err := os.CreateTemp(t.TempDir(), "text")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	if err := warning.RenderText(file, warning.NewSliceReader([]warning.Warning{warning.New("test")}), warning.TextOptions{}); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	content, err := os.ReadFile(file.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(string(content), "\x1b[") {
+		t.Errorf("expected no colour, got %q", content)
+	}
+}
@@ -0,0 +1,173 @@
+package warning
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// typeRegistry maps the names of custom warning types to their constructors, and back.
+type typeRegistry struct {
+	byName map[string]func() Warning
+	byType map[reflect.Type]string
+	mtx    sync.RWMutex
+}
+
+var types = &typeRegistry{ //nolint:gochecknoglobals
+	byName: make(map[string]func() Warning),
+	byType: make(map[reflect.Type]string),
+}
+
+// RegisterType registers a custom warning type under name, so that [NewJSONWriter] records the name
+// along with the JSON encoding of the warning, and [NewJSONReader] decodes it back into the same type.
+// newFunc must return a new pointer to the type, the data is decoded into it with [json.Unmarshal].
+//
+//	warning.MustRegisterType("example.com/multi", func() warning.Warning { return new(MultiWarning) })
+//
+// It returns [ErrInvalidType] if the name is empty or newFunc does not return a pointer,
+// and [ErrDuplicateType] if the name or the type is already registered.
+func RegisterType(name string, newFunc func() Warning) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("%w: empty name", ErrInvalidType)
+	}
+
+	if newFunc == nil {
+		return fmt.Errorf("%w: nil constructor for %s", ErrInvalidType, name)
+	}
+
+	typ := reflect.TypeOf(newFunc())
+	if typ == nil || typ.Kind() != reflect.Pointer {
+		return fmt.Errorf("%w: %s is not a pointer type", ErrInvalidType, name)
+	}
+
+	types.mtx.Lock()
+	defer types.mtx.Unlock()
+
+	if _, ok := types.byName[name]; ok {
+		return fmt.Errorf("%w: %s", ErrDuplicateType, name)
+	}
+
+	if found, ok := types.byType[typ]; ok {
+		return fmt.Errorf("%w: %s is already registered as %s", ErrDuplicateType, typ, found)
+	}
+
+	types.byName[name] = newFunc
+	types.byType[typ] = name
+
+	return nil
+}
+
+// MustRegisterType is like [RegisterType] but panics if the type cannot be registered.
+// It is intended for package initialization.
+func MustRegisterType(name string, newFunc func() Warning) {
+	if err := RegisterType(name, newFunc); err != nil {
+		panic(err)
+	}
+}
+
+// encodeType returns the name and the JSON encoding of the outermost warning in the chain whose type is
+// registered. Warnings of unknown types decoded by [NewJSONReader] keep their original name and data.
+func encodeType(wrr Warning) (string, json.RawMessage, error) {
+	types.mtx.RLock()
+	defer types.mtx.RUnlock()
+
+	for ; wrr != nil; wrr = Unwrap(wrr) {
+		if unknown, ok := wrr.(*unknownTypeWarning); ok {
+			return unknown.name, unknown.data, nil
+		}
+
+		name, ok := types.byType[reflect.TypeOf(wrr)]
+		if !ok {
+			continue
+		}
+
+		data, err := json.Marshal(wrr)
+		if err != nil {
+			return "", nil, fmt.Errorf("encode %s: %w", name, err)
+		}
+
+		return name, data, nil
+	}
+
+	return "", nil, nil
+}
+
+// decodeType returns a new warning of the type registered under name, decoded from data.
+// If no type is registered under name, it returns a generic warning with the message that keeps
+// the name and data, so that they are written again when the warning is encoded.
+func decodeType(name string, data json.RawMessage, msg string) (Warning, error) {
+	if name == "" {
+		return New(msg), nil
+	}
+
+	types.mtx.RLock()
+	newFunc, ok := types.byName[name]
+	types.mtx.RUnlock()
+
+	if !ok {
+		return &unknownTypeWarning{New(msg), name, data}, nil
+	}
+
+	wrr := newFunc()
+
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, wrr); err != nil {
+			return nil, fmt.Errorf("decode %s: %w", name, err)
+		}
+	}
+
+	return wrr, nil
+}
+
+type unknownTypeWarning struct {
+	wrr  Warning
+	name string
+	data json.RawMessage
+}
+
+func (wrr *unknownTypeWarning) Warn() string {
+	return wrr.wrr.Warn()
+}
+
+func (wrr *unknownTypeWarning) Format(state fmt.State, verb rune) {
+	formatState(state, verb, wrr)
+}
+
+func (wrr *unknownTypeWarning) String() string {
+	return stringOf(wrr.wrr)
+}
+
+// MarshalJSON returns the data the warning was decoded with, as the warning of the unregistered type would.
+func (wrr *unknownTypeWarning) MarshalJSON() ([]byte, error) {
+	if len(wrr.data) > 0 {
+		return wrr.data, nil
+	}
+
+	return json.Marshal(wrr.wrr)
+}
+
+func (wrr *unknownTypeWarning) Unwrap() Warning {
+	return wrr.wrr
+}
+
+// TypeOf returns the name the type of the warning, or of any warning it wraps, is registered under with
+// [RegisterType]. For warnings of unregistered types decoded by [NewJSONReader], it returns the encoded name.
+// It returns an empty string if no type is registered.
+func TypeOf(wrr Warning) string {
+	types.mtx.RLock()
+	defer types.mtx.RUnlock()
+
+	for ; wrr != nil; wrr = Unwrap(wrr) {
+		if unknown, ok := wrr.(*unknownTypeWarning); ok {
+			return unknown.name
+		}
+
+		if name, ok := types.byType[reflect.TypeOf(wrr)]; ok {
+			return name
+		}
+	}
+
+	return ""
+}
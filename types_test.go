@@ -0,0 +1,179 @@
+package warning_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"go.wamod.dev/warning"
+)
+
+type batchWarning struct {
+	Details []string `json:"details"`
+}
+
+func (w *batchWarning) Warn() string {
+	return strings.Join(w.Details, ", ")
+}
+
+type codedWarning struct {
+	Msg string `json:"msg"`
+	ID  string `json:"id"`
+}
+
+func (w *codedWarning) Warn() string {
+	return w.Msg
+}
+
+func (w *codedWarning) Code() string {
+	return w.ID
+}
+
+type valueWarning string
+
+func (w valueWarning) Warn() string {
+	return string(w)
+}
+
+var registerTypesOnce sync.Once //nolint:gochecknoglobals
+
+// registerTypes registers the custom warning types of the tests. Types are registered globally,
+// so this is only done once no matter how many times the tests run.
+func registerTypes() {
+	registerTypesOnce.Do(func() {
+		warning.MustRegisterType("test/batch", func() warning.Warning { return new(batchWarning) })
+		warning.MustRegisterType("test/coded", func() warning.Warning { return new(codedWarning) })
+	})
+}
+
+func roundTrip(t *testing.T, wrr warning.Warning) (warning.Warning, string) {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	if err := warning.NewJSONWriter(&buf).WriteWarning(wrr); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	line := buf.String()
+
+	decoded, err := warning.NewJSONReader(&buf).ReadWarning()
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	return decoded, line
+}
+
+func TestRegisterType(t *testing.T) {
+	registerTypes()
+
+	writer := &mockWriter{}
+	ctx := warning.WithScope(warning.Attach(context.Background(), writer), "batch")
+	warning.Warn(ctx, warning.WithCode(&batchWarning{[]string{"a", "b"}}, "W1"))
+
+	decoded, line := roundTrip(t, writer.buf[0])
+
+	if !strings.Contains(line, `"type":"test/batch","data":{"details":["a","b"]}`) {
+		t.Errorf("expected type and data in %s", line)
+	}
+
+	if warning.TypeOf(decoded) != "test/batch" {
+		t.Errorf("expected type test/batch, got %q", warning.TypeOf(decoded))
+	}
+
+	var base *batchWarning
+
+	for wrr := decoded; wrr != nil; wrr = warning.Unwrap(wrr) {
+		if found, ok := wrr.(*batchWarning); ok {
+			base = found
+		}
+	}
+
+	if base == nil || base.Warn() != "a, b" {
+		t.Fatalf("expected decoded *batchWarning, got %v", decoded)
+	}
+
+	assertSameWarning(t, writer.buf[0], decoded)
+}
+
+func TestRegisterType_OwnMetadata(t *testing.T) {
+	registerTypes()
+
+	decoded, _ := roundTrip(t, &codedWarning{"test", "W7"})
+
+	got, ok := decoded.(*codedWarning)
+	if !ok {
+		t.Fatalf("expected *codedWarning without wrappers, got %T", decoded)
+	}
+
+	if got.ID != "W7" || warning.CodeOf(decoded) != "W7" {
+		t.Errorf("expected code W7, got %v", warning.CodeOf(decoded))
+	}
+}
+
+func TestRegisterType_Unknown(t *testing.T) {
+	data := `{"version":1,"type":"other/custom","data":{"x":1},"message":"test","code":"W1"}` + "\n"
+
+	decoded, err := warning.NewJSONReader(strings.NewReader(data)).ReadWarning()
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	if decoded.Warn() != "test" || warning.CodeOf(decoded) != "W1" {
+		t.Errorf("expected generic warning, got %+v", decoded)
+	}
+
+	if warning.TypeOf(decoded) != "other/custom" {
+		t.Errorf("expected type other/custom, got %q", warning.TypeOf(decoded))
+	}
+
+	if _, line := roundTrip(t, decoded); !strings.Contains(line, `"type":"other/custom","data":{"x":1}`) {
+		t.Errorf("expected type and data to be preserved, got %s", line)
+	}
+
+	// the decoded warning encodes as the warning of the unregistered type would
+	if got, err := json.Marshal(decoded); err != nil || string(got) != `{"x":1}` {
+		t.Errorf(`expected {"x":1}, got %s, %v`, got, err)
+	}
+}
+
+func TestRegisterType_Errors(t *testing.T) {
+	registerTypes()
+
+	tests := []struct {
+		name    string
+		typ     string
+		newFunc func() warning.Warning
+		err     error
+	}{
+		{"empty name", " ", func() warning.Warning { return new(batchWarning) }, warning.ErrInvalidType},
+		{"nil constructor", "test/nil", nil, warning.ErrInvalidType},
+		{"not a pointer", "test/value", func() warning.Warning { return valueWarning("") }, warning.ErrInvalidType},
+		{"duplicate name", "test/batch", func() warning.Warning { return new(codedWarning) }, warning.ErrDuplicateType},
+		{"duplicate type", "test/batch2", func() warning.Warning { return new(batchWarning) }, warning.ErrDuplicateType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := warning.RegisterType(tt.typ, tt.newFunc); !errors.Is(err, tt.err) {
+				t.Errorf("expected %v, got %v", tt.err, err)
+			}
+		})
+	}
+
+	data := `{"version":1,"type":"test/batch","data":{"details":"a"},"message":"a"}`
+
+	if _, err := warning.NewJSONReader(strings.NewReader(data)).ReadWarning(); err == nil ||
+		!strings.Contains(err.Error(), "line 1: decode test/batch") {
+		t.Errorf("expected decode error, got %v", err)
+	}
+
+	if warning.TypeOf(warning.New("test")) != "" {
+		t.Errorf("expected no type for built-in warnings")
+	}
+}
@@ -32,6 +32,11 @@
 //
 //	ctx = warning.Detach(ctx)
 //
+// Warnings written to a context without an attached writer go to the [Default] writer,
+// which discards them unless changed with [SetDefault].
+//
+//	warning.SetDefault(warning.NewSnippetWriter(os.Stderr, os.ReadFile))
+//
 // Use [Map], [Filter], [Reduce] or [Tap] helper functions to apply transformations,
 // filters or side-effects to the warnings.
 package warning
@@ -41,6 +46,9 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
 )
 
 // Warning is an interface representing a warning.
@@ -56,6 +64,10 @@ func (wrr *warningString) Warn() string {
 	return wrr.msg
 }
 
+func (wrr *warningString) Format(state fmt.State, verb rune) {
+	formatState(state, verb, wrr)
+}
+
 func (wrr *warningString) String() string {
 	return wrr.msg
 }
@@ -69,23 +81,125 @@ func New(msg string) Warning {
 	return &warningString{msg}
 }
 
+// Unwrap returns the result of calling the Unwrap method on wrr, if wrr's type contains
+// an Unwrap method returning [Warning]. Otherwise, Unwrap returns nil.
+func Unwrap(wrr Warning) Warning {
+	u, ok := wrr.(interface{ Unwrap() Warning })
+	if !ok {
+		return nil
+	}
+
+	return u.Unwrap()
+}
+
+// As walks the chain of wrapped warnings and returns the first one of type T, e.g. to reach the concrete type
+// of a warning wrapped by [WithCode] or stamped by a [Collector]. T may also be an interface type.
+func As[T any](wrr Warning) (T, bool) {
+	return find[T](wrr)
+}
+
+// find walks the chain of wrapped warnings and returns the first one implementing T.
+func find[T any](wrr Warning) (T, bool) {
+	for wrr != nil {
+		if found, ok := wrr.(T); ok {
+			return found, true
+		}
+
+		wrr = Unwrap(wrr)
+	}
+
+	return *new(T), false
+}
+
+// stringOf returns the string form of the warning, its message unless it implements [fmt.Stringer].
+func stringOf(wrr Warning) string {
+	if s, ok := wrr.(fmt.Stringer); ok {
+		return s.String()
+	}
+
+	return wrr.Warn()
+}
+
 type writerKey struct{}
 
 func setWriter(ctx context.Context, w Writer) context.Context {
 	return context.WithValue(ctx, writerKey{}, w)
 }
 
-func getWriter(ctx context.Context) Writer {
+// attachedWriter returns the writer attached to the context, ignoring the default writer.
+func attachedWriter(ctx context.Context) (Writer, bool) {
 	writer, ok := ctx.Value(writerKey{}).(Writer)
+
+	return writer, ok
+}
+
+// getWriter returns the writer attached to the context, or the default writer if none is attached.
+// It returns nil if warnings written to the context are discarded.
+func getWriter(ctx context.Context) Writer {
+	writer, ok := attachedWriter(ctx)
 	if !ok {
+		if Default() == Discard {
+			return nil
+		}
+
+		writer = defaultProxy{}
+	}
+
+	if writer == Discard {
 		return nil
 	}
 
 	return writer
 }
 
+// Discard is a [Writer] on which all WriteWarning calls succeed without doing anything.
+var Discard Writer = discard{} //nolint:gochecknoglobals
+
+type discard struct{}
+
+func (discard) WriteWarning(Warning) error {
+	return nil
+}
+
+var defaultWriter atomic.Pointer[Writer] //nolint:gochecknoglobals
+
+// SetDefault makes w the default writer, used for contexts that have no writer attached,
+// such as [context.Background]. Passing nil restores the stock default, [Discard].
+// Contexts returned by [Detach] never use the default writer. While the default writer is Discard,
+// helpers such as [Map] or [Filter] leave contexts without an attached writer unchanged.
+func SetDefault(w Writer) {
+	if w == nil {
+		w = Discard
+	}
+
+	defaultWriter.Store(&w)
+}
+
+// Default returns the default writer. Unless changed with [SetDefault], it is [Discard].
+func Default() Writer {
+	if w := defaultWriter.Load(); w != nil {
+		return *w
+	}
+
+	return Discard
+}
+
+// defaultProxy forwards warnings to the default writer at the time they are written,
+// so contexts derived before a call to [SetDefault] follow the change.
+type defaultProxy struct{}
+
+func (defaultProxy) WriteWarning(wrr Warning) error {
+	return Default().WriteWarning(wrr)
+}
+
+func (defaultProxy) WriteWarningContext(ctx context.Context, wrr Warning) error {
+	return writeWarning(ctx, Default(), wrr)
+}
+
 // Warn writes warning to the context. When multiple warnings are provided, they are written in order.
-// If no writer is attached to the context, it does nothing and returns nil.
+// If no writer is attached to the context, warnings are written to the [Default] writer.
+// Writers implementing [ContextWriter] receive ctx along with each warning.
+// Warnings are written as is, they are stamped once they reach a [Collector] or a [ShardedCollector].
 // If any of the warning fail to write, all the warnings are returned as one error.
 func Warn(ctx context.Context, wrrs ...Warning) error {
 	writer := getWriter(ctx)
@@ -96,7 +210,7 @@ func Warn(ctx context.Context, wrrs ...Warning) error {
 	var errs []error
 
 	for _, wrr := range wrrs {
-		if err := writer.WriteWarning(wrr); err != nil {
+		if err := writeWarning(ctx, writer, wrr); err != nil {
 			errs = append(errs, err)
 		}
 	}
@@ -106,20 +220,69 @@ func Warn(ctx context.Context, wrrs ...Warning) error {
 
 // Warnf is a helper function that formats the warning and writes it to the context.
 // If the format string contains any [Warning] arguments, they are converted to strings before formatting.
+//
+// The args slice is never modified. If no writer is attached to the context, Warnf returns without
+// formatting or allocating. Otherwise the message is formatted when it is first needed, so warnings
+// dropped by a filter are never formatted; values pointed to by args should not be modified after the call.
 func Warnf(ctx context.Context, format string, args ...any) error {
+	writer := getWriter(ctx)
+	if writer == nil {
+		return nil
+	}
+
+	return Warn(ctx, &formatWarning{format: format, args: slices.Clone(args)})
+}
+
+// formatWarning is a warning whose message is formatted on first use.
+type formatWarning struct {
+	format string
+	args   []any
+	once   sync.Once
+	msg    string
+}
+
+func (wrr *formatWarning) Warn() string {
+	wrr.once.Do(func() {
+		wrr.msg = sprintf(wrr.format, wrr.args)
+		wrr.args = nil
+	})
+
+	return wrr.msg
+}
+
+func (wrr *formatWarning) Format(state fmt.State, verb rune) {
+	formatState(state, verb, wrr)
+}
+
+func (wrr *formatWarning) String() string {
+	return wrr.Warn()
+}
+
+func (wrr *formatWarning) MarshalJSON() ([]byte, error) {
+	return json.Marshal(wrr.Warn())
+}
+
+// sprintf formats the message like [fmt.Sprintf], converting [Warning] arguments to strings.
+// The args slice is left untouched.
+func sprintf(format string, args []any) string {
+	converted := make([]any, len(args))
+
 	for i, arg := range args {
 		if wrr, ok := arg.(Warning); ok {
-			args[i] = wrr.Warn()
+			arg = wrr.Warn()
 		}
+
+		converted[i] = arg
 	}
 
-	return Warn(ctx, New(fmt.Sprintf(format, args...)))
+	return fmt.Sprintf(format, converted...)
 }
 
 // Attach returns a new context that collects warnings using the provided writer.
 // If a writer is already attached to the context, it creates a new writer that writes to both.
+// The default writer is not used by the returned context.
 func Attach(ctx context.Context, writer Writer) context.Context {
-	if found := getWriter(ctx); found != nil {
+	if found, ok := attachedWriter(ctx); ok && found != Discard {
 		writer = NewMultiWriter(found, writer)
 	}
 
@@ -127,11 +290,7 @@ func Attach(ctx context.Context, writer Writer) context.Context {
 }
 
 // Detach returns a new context that does not propagate warnings up the chain.
+// Warnings written to the returned context are discarded, they are not written to the default writer either.
 func Detach(ctx context.Context) context.Context {
-	writer := getWriter(ctx)
-	if writer == nil {
-		return ctx
-	}
-
-	return setWriter(ctx, nil)
+	return setWriter(ctx, Discard)
 }
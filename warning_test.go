@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 
 	"go.wamod.dev/warning"
@@ -124,6 +125,115 @@ func TestWarnf(t *testing.T) {
 	}
 }
 
+func TestWarnfArgs(t *testing.T) {
+	writer := &mockWriter{}
+	ctx := warning.Attach(context.Background(), writer)
+
+	sub := warning.New("sub-warning")
+	args := []any{sub, 1}
+
+	if err := warning.Warnf(ctx, "%s %d", args...); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	if args[0] != sub {
+		t.Errorf("expected args to be unchanged, got %v", args)
+	}
+
+	// the message is formatted from a copy of the arguments
+	args[1] = 2
+
+	if got := writer.buf[0].Warn(); got != "sub-warning 1" {
+		t.Errorf("expected %q, got %q", "sub-warning 1", got)
+	}
+
+	if got := fmt.Sprint(writer.buf[0]); got != "sub-warning 1" {
+		t.Errorf("expected %q, got %q", "sub-warning 1", got)
+	}
+}
+
+func TestWarnfLazy(t *testing.T) {
+	formatted := 0
+	arg := formatterFunc(func() string {
+		formatted++
+
+		return "arg"
+	})
+
+	writer := &mockWriter{}
+	ctx := warning.Attach(context.Background(), writer)
+	ctx = warning.Filter(ctx, func(warning.Warning) bool { return false })
+
+	warning.Warnf(ctx, "%s", arg)
+
+	if formatted != 0 {
+		t.Errorf("expected filtered warning not to be formatted, got %d calls", formatted)
+	}
+}
+
+type formatterFunc func() string
+
+func (f formatterFunc) String() string {
+	return f()
+}
+
+func TestWarnfNoWriterAllocs(t *testing.T) {
+	ctx := context.Background()
+
+	allocs := testing.AllocsPerRun(100, func() {
+		warning.Warnf(ctx, "value %d of %s", 42, "name")
+	})
+
+	if allocs != 0 {
+		t.Errorf("expected zero allocations, got %v", allocs)
+	}
+}
+
+func BenchmarkWarnf(b *testing.B) {
+	b.Run("no writer", func(b *testing.B) {
+		ctx := context.Background()
+
+		b.ReportAllocs()
+
+		for range b.N {
+			warning.Warnf(ctx, "value %d of %s", 42, "name")
+		}
+	})
+
+	b.Run("detached", func(b *testing.B) {
+		ctx := warning.Detach(warning.Attach(context.Background(), &mockWriter{}))
+
+		b.ReportAllocs()
+
+		for range b.N {
+			warning.Warnf(ctx, "value %d of %s", 42, "name")
+		}
+	})
+
+	b.Run("filtered", func(b *testing.B) {
+		ctx := warning.Attach(context.Background(), &mockWriter{})
+		ctx = warning.Filter(ctx, func(warning.Warning) bool { return false })
+
+		b.ReportAllocs()
+
+		for range b.N {
+			warning.Warnf(ctx, "value %d of %s", 42, "name")
+		}
+	})
+
+	b.Run("formatted", func(b *testing.B) {
+		ctx := warning.Filter(warning.Attach(context.Background(), &mockWriter{}), func(wrr warning.Warning) bool {
+			return wrr.Warn() == ""
+		})
+
+		b.ReportAllocs()
+
+		for range b.N {
+			warning.Warnf(ctx, "value %d of %s", 42, "name")
+		}
+	})
+}
+
 func TestAttach(t *testing.T) {
 	writers := [2]*mockWriter{
 		new(mockWriter),
@@ -171,3 +281,96 @@ func TestDetachNoWriter(t *testing.T) {
 		t.Fatalf("expected nil error, got %v", err)
 	}
 }
+
+func TestSetDefault(t *testing.T) {
+	writer := &mockWriter{}
+
+	if warning.Default() != warning.Discard {
+		t.Fatalf("expected stock default to be Discard, got %v", warning.Default())
+	}
+
+	warning.SetDefault(writer)
+	defer warning.SetDefault(nil)
+
+	if warning.Default() != writer {
+		t.Fatalf("expected %v, got %v", writer, warning.Default())
+	}
+
+	want := warning.New("test-warning")
+
+	if err := warning.Warn(context.Background(), want); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	if len(writer.buf) != 1 || writer.buf[0] != want {
+		t.Fatalf("expected %v, got %v", want, writer.buf)
+	}
+}
+
+func TestSetDefaultHelpers(t *testing.T) {
+	writer := &mockWriter{}
+
+	warning.SetDefault(&mockWriter{})
+	defer warning.SetDefault(nil)
+
+	ctx := warning.Map(context.Background(), func(wrr warning.Warning) warning.Warning {
+		return warning.New(strings.ToUpper(wrr.Warn()))
+	})
+
+	// contexts derived before SetDefault follow the change
+	warning.SetDefault(writer)
+
+	ctx = warning.Filter(ctx, func(wrr warning.Warning) bool {
+		return wrr.Warn() != "ignore"
+	})
+
+	warning.Warn(ctx, warning.New("test"), warning.New("ignore"))
+
+	if len(writer.buf) != 1 || writer.buf[0].Warn() != "TEST" {
+		t.Fatalf("expected TEST, got %v", writer.buf)
+	}
+}
+
+func TestSetDefaultAttach(t *testing.T) {
+	defaultWriter := &mockWriter{}
+	writer := &mockWriter{}
+
+	warning.SetDefault(defaultWriter)
+	defer warning.SetDefault(nil)
+
+	ctx := warning.Attach(context.Background(), writer)
+	warning.Warn(ctx, warning.New("test"))
+
+	if len(writer.buf) != 1 {
+		t.Errorf("expected 1 warning, got %v", writer.buf)
+	}
+
+	if len(defaultWriter.buf) > 0 {
+		t.Errorf("expected no warnings in default writer, got %v", defaultWriter.buf)
+	}
+}
+
+func TestDetachDefault(t *testing.T) {
+	writer := &mockWriter{}
+
+	warning.SetDefault(writer)
+	defer warning.SetDefault(nil)
+
+	ctx := warning.Detach(context.Background())
+
+	if err := warning.Warn(ctx, warning.New("test-warning")); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	if len(writer.buf) > 0 {
+		t.Fatalf("expected no warning, got %v", writer.buf)
+	}
+
+	// attaching after detaching does not write to the discarded chain
+	attached := &mockWriter{}
+	warning.Warn(warning.Attach(ctx, attached), warning.New("test-warning"))
+
+	if len(attached.buf) != 1 {
+		t.Fatalf("expected 1 warning, got %v", attached.buf)
+	}
+}
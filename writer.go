@@ -1,6 +1,9 @@
 package warning
 
-import "errors"
+import (
+	"context"
+	"errors"
+)
 
 // Writer is the interface for writing warnings.
 type Writer interface {
@@ -8,20 +11,43 @@ type Writer interface {
 	WriteWarning(wrr Warning) error
 }
 
+// ContextWriter is an optional interface implemented by writers that need the context the warning
+// was written to, for example to read request or trace identifiers. [Warn] calls WriteWarningContext
+// instead of WriteWarning when the writer implements it.
+type ContextWriter interface {
+	Writer
+	// WriteWarningContext writes the warning emitted to ctx.
+	WriteWarningContext(ctx context.Context, wrr Warning) error
+}
+
+// writeWarning writes the warning to w, passing ctx along if w implements [ContextWriter].
+func writeWarning(ctx context.Context, w Writer, wrr Warning) error {
+	if cw, ok := w.(ContextWriter); ok {
+		return cw.WriteWarningContext(ctx, wrr)
+	}
+
+	return w.WriteWarning(wrr)
+}
+
 type multiWriter struct {
 	writers []Writer
 }
 
 // NewMultiWriter returns a Writer that duplicates its writes to all the provided writers.
+// The context is passed along to writers implementing [ContextWriter].
 func NewMultiWriter(writers ...Writer) Writer {
 	return &multiWriter{writers}
 }
 
 func (w *multiWriter) WriteWarning(wrr Warning) error {
+	return w.WriteWarningContext(context.Background(), wrr)
+}
+
+func (w *multiWriter) WriteWarningContext(ctx context.Context, wrr Warning) error {
 	var errs []error
 
 	for _, w := range w.writers {
-		if err := w.WriteWarning(wrr); err != nil {
+		if err := writeWarning(ctx, w, wrr); err != nil {
 			errs = append(errs, err)
 		}
 	}
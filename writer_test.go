@@ -1,6 +1,7 @@
 package warning_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"testing"
@@ -23,6 +24,62 @@ func (w *mockWriter) WriteWarning(wrr warning.Warning) error {
 	return w.result
 }
 
+type ctxKey struct{}
+
+type mockContextWriter struct {
+	mockWriter
+	values []any
+}
+
+func (w *mockContextWriter) WriteWarningContext(ctx context.Context, wrr warning.Warning) error {
+	w.values = append(w.values, ctx.Value(ctxKey{}))
+
+	return w.WriteWarning(wrr)
+}
+
+func TestContextWriter(t *testing.T) {
+	writer := &mockContextWriter{}
+	plain := &mockWriter{}
+
+	ctx := warning.Attach(context.Background(), writer)
+	ctx = warning.Attach(ctx, plain)
+	ctx = warning.Map(ctx, func(wrr warning.Warning) warning.Warning { return wrr })
+	ctx = warning.Filter(ctx, func(warning.Warning) bool { return true })
+	ctx = warning.Tap(ctx, func(warning.Warning) {})
+	ctx = warning.WithField(ctx, "spec")
+	ctx = warning.WithScope(ctx, "db")
+	ctx = context.WithValue(ctx, ctxKey{}, "request-1")
+
+	if err := warning.Warn(ctx, warning.New("test")); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	if len(writer.values) != 1 || writer.values[0] != "request-1" {
+		t.Errorf("expected context value %q, got %v", "request-1", writer.values)
+	}
+
+	if len(plain.buf) != 1 {
+		t.Errorf("expected 1 warning in plain writer, got %v", plain.buf)
+	}
+}
+
+func TestContextWriterDefault(t *testing.T) {
+	writer := &mockContextWriter{}
+
+	warning.SetDefault(writer)
+	defer warning.SetDefault(nil)
+
+	ctx := context.WithValue(context.Background(), ctxKey{}, "request-1")
+
+	if err := warning.Warn(ctx, warning.New("test")); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	if len(writer.values) != 1 || writer.values[0] != "request-1" {
+		t.Errorf("expected context value %q, got %v", "request-1", writer.values)
+	}
+}
+
 func TestNewMultiWriter(t *testing.T) {
 	writers := []*mockWriter{
 		{result: nil},